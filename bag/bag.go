@@ -0,0 +1,118 @@
+package immutable_bag
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/comparator"
+	immutable_map "github.com/freebirdljj/immutable/map"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+type (
+	// The zero value of `Bag` makes nonsense.
+	// A bag (multiset) is a map from distinct values to their positive multiplicities.
+	Bag[Value any] struct {
+		multiplicities *immutable_map.Map[Value, int]
+		size           int
+	}
+)
+
+func New[Value any](cmp comparator.Comparator[Value]) *Bag[Value] {
+	return &Bag[Value]{
+		multiplicities: immutable_map.New[Value, int](cmp),
+	}
+}
+
+func FromValues[Value any](cmp comparator.Comparator[Value], values ...Value) *Bag[Value] {
+	b := New(cmp)
+	for _, value := range values {
+		b = b.Add(value)
+	}
+	return b
+}
+
+func (b *Bag[Value]) Empty() bool {
+	return b.size == 0
+}
+
+// `Len()` returns the number of values counted with multiplicity.
+func (b *Bag[Value]) Len() int {
+	return b.size
+}
+
+// `CountDistinct()` returns the number of distinct values.
+func (b *Bag[Value]) CountDistinct() int {
+	return b.multiplicities.Count()
+}
+
+// `Count(value)` returns the multiplicity of `value`, which is 0 if absent.
+func (b *Bag[Value]) Count(value Value) int {
+	multiplicity, _ := b.multiplicities.Index(value)
+	return multiplicity
+}
+
+// `Add(value)` returns a bag with the multiplicity of `value` increased by one.
+func (b *Bag[Value]) Add(value Value) *Bag[Value] {
+	newMultiplicities, _ := b.multiplicities.Alter(value, func(old maybe.Maybe[int]) maybe.Maybe[int] {
+		return maybe.Just(old.OrValue(0) + 1)
+	})
+	return b.with(newMultiplicities, b.size+1)
+}
+
+// `RemoveOne(value)` returns a bag with the multiplicity of `value` decreased by one.
+// `affected` is true, meaning that `value` exists; otherwise nothing happens, `newBag` is the original one.
+func (b *Bag[Value]) RemoveOne(value Value) (newBag *Bag[Value], affected bool) {
+
+	newMultiplicities, old := b.multiplicities.Alter(value, func(old maybe.Maybe[int]) maybe.Maybe[int] {
+		return maybe.Bind(old, func(multiplicity int) maybe.Maybe[int] {
+			if multiplicity == 1 {
+				return maybe.Nothing[int]()
+			}
+			return maybe.Just(multiplicity - 1)
+		})
+	})
+
+	if old.IsNothing() {
+		return b, false
+	}
+	return b.with(newMultiplicities, b.size-1), true
+}
+
+// `RemoveAll(value)` returns a bag without `value`, together with the multiplicity `value` had.
+// Nothing happens if `removed` is 0, `newBag` is the original one.
+func (b *Bag[Value]) RemoveAll(value Value) (newBag *Bag[Value], removed int) {
+
+	newMultiplicities, old := b.multiplicities.Alter(value, func(maybe.Maybe[int]) maybe.Maybe[int] {
+		return maybe.Nothing[int]()
+	})
+
+	if old.IsNothing() {
+		return b, 0
+	}
+	return b.with(newMultiplicities, b.size-old.Value()), old.Value()
+}
+
+// `All()` returns an iterator of all distinct values with their multiplicities in ascending order.
+func (b *Bag[Value]) All() iter.Seq2[Value, int] {
+	return b.multiplicities.All()
+}
+
+// `Elements()` returns an iterator of all values in ascending order, each repeated as many times as its multiplicity.
+func (b *Bag[Value]) Elements() iter.Seq[Value] {
+	return func(yield func(Value) bool) {
+		for value, multiplicity := range b.All() {
+			for range multiplicity {
+				if !yield(value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *Bag[Value]) with(multiplicities *immutable_map.Map[Value, int], size int) *Bag[Value] {
+	return &Bag[Value]{
+		multiplicities: multiplicities,
+		size:           size,
+	}
+}
@@ -0,0 +1,53 @@
+package immutable_bag
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+// `countAll()` counts the multiplicity of every value of `xs` as a reference implementation.
+func countAll[Value comparable](xs []Value) map[Value]int {
+	counts := map[Value]int{}
+	for _, x := range xs {
+		counts[x]++
+	}
+	return counts
+}
+
+func TestBag(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromValues(xs) should count every value of xs": func(xs []int8) bool {
+
+			b := FromValues(comparator.OrderedComparator[int8], xs...)
+
+			return maps.Equal(maps.Collect(b.All()), countAll(xs)) &&
+				slices.Equal(slices.Collect(b.Elements()), slices.Sorted(slices.Values(xs))) &&
+				b.Len() == len(xs) &&
+				b.CountDistinct() == len(countAll(xs))
+		},
+		"b.removeOne(x).count(x) == max(b.count(x) - 1, 0)": func(xs []int8, x int8) bool {
+
+			b := FromValues(comparator.OrderedComparator[int8], xs...)
+
+			newB, affected := b.RemoveOne(x)
+			return newB.Count(x) == max(b.Count(x)-1, 0) &&
+				affected == (b.Count(x) > 0) &&
+				newB.Len() == b.Len()-b.Count(x)+newB.Count(x) &&
+				(affected || newB == b)
+		},
+		"b.removeAll(x).count(x) == 0": func(xs []int8, x int8) bool {
+
+			b := FromValues(comparator.OrderedComparator[int8], xs...)
+
+			newB, removed := b.RemoveAll(x)
+			return newB.Count(x) == 0 &&
+				removed == b.Count(x) &&
+				newB.Len() == b.Len()-removed &&
+				(removed > 0 || newB == b)
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package deque
+
+import (
+	"testing"
+)
+
+// `BenchmarkDequePopFrontPersistently` pops repeatedly from one version right before a rebalance,
+// which would cost O(n) per pop if the rebalance were not suspended and memoised.
+func BenchmarkDequePopFrontPersistently(b *testing.B) {
+
+	const n = 100_000
+
+	d := Deque[int]{}
+	for i := range n {
+		d = d.PushBack(i)
+	}
+	for d.rearLen <= balanceFactor*(d.frontLen-1)+1 {
+		d, _ = d.PopFront()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		d.PopFront()
+	}
+}
@@ -0,0 +1,138 @@
+// Implementation of Banker's Deques
+//
+// References:
+// - Purely Functional Data Structures, Section 8.4.2: https://www.cs.cmu.edu/~rwh/students/okasaki.pdf
+package deque
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/list/lazy"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+type (
+	// The zero value of `Deque` is an empty deque.
+	// Elements are kept in `front` followed by `rear` in reverse order,
+	// and neither of them is allowed to be more than `balanceFactor` times as long as the other (plus one),
+	// so that both ends are always within reach.
+	// Both are lazy lists, so that rebalancing is suspended and memoised,
+	// which keeps operations amortised O(1) even if a version is used more than once.
+	Deque[T any] struct {
+		front    *lazy.List[T]
+		frontLen int
+		rear     *lazy.List[T]
+		rearLen  int
+	}
+)
+
+const balanceFactor = 3
+
+func (d Deque[T]) Empty() bool {
+	return d.Len() == 0
+}
+
+func (d Deque[T]) Len() int {
+	return d.frontLen + d.rearLen
+}
+
+func (d Deque[T]) PushFront(x T) Deque[T] {
+	return d.flip().PushBack(x).flip()
+}
+
+func (d Deque[T]) PushBack(x T) Deque[T] {
+	rear := d.rear
+	d.rear = lazy.Cons(x, func() *lazy.List[T] { return rear })
+	d.rearLen++
+	return d.balance()
+}
+
+// `PopFront()` returns a deque with the first element removed, together with that element,
+// or nothing happens if `d` is empty.
+func (d Deque[T]) PopFront() (newDeque Deque[T], first maybe.Maybe[T]) {
+	flipped, first := d.flip().PopBack()
+	return flipped.flip(), first
+}
+
+// `PopBack()` returns a deque with the last element removed, together with that element,
+// or nothing happens if `d` is empty.
+func (d Deque[T]) PopBack() (newDeque Deque[T], last maybe.Maybe[T]) {
+
+	switch {
+	case d.rearLen > 0:
+		x, rest := d.rear.Uncons()
+		d.rear = rest
+		d.rearLen--
+		return d.balance(), maybe.Just(x)
+	case d.frontLen > 0:
+		// NOTE: The balance invariant guarantees that `front` holds only one element here.
+		return Deque[T]{}, head(d.front)
+	default:
+		return d, maybe.Nothing[T]()
+	}
+}
+
+func (d Deque[T]) PeekFront() maybe.Maybe[T] {
+	return d.flip().PeekBack()
+}
+
+func (d Deque[T]) PeekBack() maybe.Maybe[T] {
+	if d.rearLen > 0 {
+		return head(d.rear)
+	}
+	return head(d.front)
+}
+
+// `All()` returns an iterator of all elements from front to back.
+func (d Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, xs := range []*lazy.List[T]{d.front, d.rear.Reverse()} {
+			for x := range xs.All() {
+				if !yield(x) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// `flip()` returns `d` in reverse order, which allows operations on the front to be expressed by those on the back.
+func (d Deque[T]) flip() Deque[T] {
+	return Deque[T]{
+		front:    d.rear,
+		frontLen: d.rearLen,
+		rear:     d.front,
+		rearLen:  d.frontLen,
+	}
+}
+
+// `balance()` splits the elements evenly between `front` and `rear` once one of them gets too long.
+// NOTE: The reversal is only performed once the new `front` is consumed up to it,
+// and the result is shared by all versions derived from the returned one.
+func (d Deque[T]) balance() Deque[T] {
+
+	switch {
+	case d.frontLen > balanceFactor*d.rearLen+1:
+		return d.flip().balance().flip()
+	case d.rearLen > balanceFactor*d.frontLen+1:
+		n := d.Len()
+		rearLen := n / 2
+		rear := d.rear
+		return Deque[T]{
+			front:    d.front.AppendFunc(func() *lazy.List[T] { return lazy.Drop(rear, rearLen).Reverse() }),
+			frontLen: n - rearLen,
+			rear:     lazy.Take(rear, rearLen),
+			rearLen:  rearLen,
+		}
+	default:
+		return d
+	}
+}
+
+// `head()` returns the first element of `xs` without evaluating its tail.
+func head[T any](xs *lazy.List[T]) maybe.Maybe[T] {
+	for x := range xs.All() {
+		return maybe.Just(x)
+	}
+	return maybe.Nothing[T]()
+}
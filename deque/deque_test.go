@@ -0,0 +1,79 @@
+package deque
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+// `simulate()` applies `ops` to both a deque and a go slice, where each op pushes to or pops from either end,
+// and reports whether they always agree.
+func simulate(ops []int8) bool {
+
+	d, xs := Deque[int8]{}, []int8(nil)
+
+	for _, op := range ops {
+
+		switch op & 3 {
+		case 0:
+			d, xs = d.PushFront(op), append([]int8{op}, xs...)
+		case 1:
+			d, xs = d.PushBack(op), append(xs, op)
+		case 2:
+			newD, first := d.PopFront()
+			if first.IsJust() != (len(xs) > 0) || (first.IsJust() && first.Value() != xs[0]) {
+				return false
+			}
+			d, xs = newD, xs[min(len(xs), 1):]
+		case 3:
+			newD, last := d.PopBack()
+			if last.IsJust() != (len(xs) > 0) || (last.IsJust() && last.Value() != xs[len(xs)-1]) {
+				return false
+			}
+			d, xs = newD, xs[:max(len(xs)-1, 0)]
+		}
+
+		if d.Len() != len(xs) || !slices.Equal(slices.Collect(d.All()), xs) || !isBalanced(d) {
+			return false
+		}
+
+		first, last := d.PeekFront(), d.PeekBack()
+		if first.IsJust() != (len(xs) > 0) || last.IsJust() != (len(xs) > 0) ||
+			(len(xs) > 0 && (first.Value() != xs[0] || last.Value() != xs[len(xs)-1])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestDeque(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"deque should behave like a go slice": simulate,
+		"popping should never affect the original deque": func(xs []int) bool {
+
+			d := Deque[int]{}
+			for _, x := range xs {
+				d = d.PushBack(x)
+			}
+
+			for range xs {
+				d.PopFront()
+				d.PopBack()
+			}
+
+			return slices.Equal(slices.Collect(d.All()), xs)
+		},
+		"popping an empty deque yields nothing": func() bool {
+			d, first := Deque[int]{}.PopFront()
+			_, last := d.PopBack()
+			return first.IsNothing() && last.IsNothing() && d.Empty()
+		},
+	})
+}
+
+func isBalanced[T any](d Deque[T]) bool {
+	return d.frontLen == len(d.front.ToGoSlice()) && d.rearLen == len(d.rear.ToGoSlice()) &&
+		d.frontLen <= balanceFactor*d.rearLen+1 && d.rearLen <= balanceFactor*d.frontLen+1
+}
@@ -0,0 +1,30 @@
+package immutable_hashmap
+
+import (
+	"hash/maphash"
+)
+
+type (
+	integer interface {
+		~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+	}
+)
+
+// `StringHasher()` hashes strings by `maphash.String()` with a random seed.
+func StringHasher() Hasher[string] {
+	seed := maphash.MakeSeed()
+	return func(key string) uint64 {
+		return maphash.String(seed, key)
+	}
+}
+
+// `IntegerHasher()` hashes integers by the finalizer of SplitMix64,
+// which spreads consecutive integers over all bits.
+func IntegerHasher[Key integer]() Hasher[Key] {
+	return func(key Key) uint64 {
+		h := uint64(key)
+		h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+		h = (h ^ h>>27) * 0x94d049bb133111eb
+		return h ^ h>>31
+	}
+}
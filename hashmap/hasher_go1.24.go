@@ -0,0 +1,16 @@
+//go:build go1.24
+
+package immutable_hashmap
+
+import (
+	"hash/maphash"
+)
+
+// `ComparableHasher()` hashes keys of any comparable type by `maphash.Comparable()` with a random seed.
+// NOTE: Only available with Go 1.24 or later.
+func ComparableHasher[Key comparable]() Hasher[Key] {
+	seed := maphash.MakeSeed()
+	return func(key Key) uint64 {
+		return maphash.Comparable(seed, key)
+	}
+}
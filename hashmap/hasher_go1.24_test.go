@@ -0,0 +1,18 @@
+//go:build go1.24
+
+package immutable_hashmap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+func TestComparableHasher(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"maps hashing comparable keys should behave like go maps": func(xs map[[2]int16]string) bool {
+			return reflect.DeepEqual(ToGoMap(FromGoMap(ComparableHasher[[2]int16](), xs)), xs)
+		},
+	})
+}
@@ -0,0 +1,322 @@
+// Implementation of Hash Array Mapped Tries
+//
+// References:
+// - Ideal Hash Trees: https://infoscience.epfl.ch/record/64398/files/idealhashtrees.pdf
+package immutable_hashmap
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+
+	immutable_func "github.com/freebirdljj/immutable/func"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+type (
+	// The zero value of `Map` makes nonsense.
+	Map[Key comparable, Value any] struct {
+		hasher Hasher[Key]
+		cnt    int
+		root   *node[Key, Value]
+	}
+
+	// `Hasher` must return equal hashes for equal keys.
+	Hasher[Key any] func(key Key) uint64
+
+	// A node at depth `hashBits / bitsPerLevel` or deeper has run out of hash bits,
+	// its `slots` hold leaves whose keys share a full hash, in no particular order.
+	// Otherwise the `i`-th slot corresponds to the `i`-th lowest set bit of `bitmap`.
+	node[Key comparable, Value any] struct {
+		bitmap uint32
+		slots  []slot[Key, Value]
+	}
+
+	// A slot is either a subtrie if `child` is not nil, or a leaf holding a key-value pair.
+	slot[Key comparable, Value any] struct {
+		child *node[Key, Value]
+		hash  uint64
+		key   Key
+		value Value
+	}
+)
+
+const (
+	hashBits     = 64
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+)
+
+func New[Key comparable, Value any](hasher Hasher[Key]) *Map[Key, Value] {
+	return &Map[Key, Value]{
+		hasher: hasher,
+	}
+}
+
+func FromGoMap[Key comparable, Value any](hasher Hasher[Key], goMap map[Key]Value) *Map[Key, Value] {
+	m := New[Key, Value](hasher)
+	for key, value := range goMap {
+		m, _ = m.Insert(key, value)
+	}
+	return m
+}
+
+func ToGoMap[Key comparable, Value any](m *Map[Key, Value]) map[Key]Value {
+	goMap := make(map[Key]Value, m.Count())
+	for key, value := range m.All() {
+		goMap[key] = value
+	}
+	return goMap
+}
+
+func (m *Map[Key, Value]) Empty() bool {
+	return m.cnt == 0
+}
+
+func (m *Map[Key, Value]) Count() int {
+	return m.cnt
+}
+
+func (m *Map[Key, Value]) Index(key Key) (value Value, has bool) {
+	leaf := m.root.lookup(m.hasher(key), key, 0)
+	if leaf == nil {
+		return immutable_func.Zero[Value](), false
+	}
+	return leaf.value, true
+}
+
+// `All()` returns an iterator of all key-value pairs in no particular order.
+func (m *Map[Key, Value]) All() iter.Seq2[Key, Value] {
+	return func(yield func(Key, Value) bool) {
+		m.root.traverse(yield)
+	}
+}
+
+func (m *Map[Key, Value]) KeyValuePairs() []tuple.KeyValuePair[Key, Value] {
+	kvPairs := make([]tuple.KeyValuePair[Key, Value], 0, m.Count())
+	for key, value := range m.All() {
+		kvPairs = append(kvPairs, tuple.KeyValuePair[Key, Value]{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return kvPairs
+}
+
+// `newMap` returned by `Insert()` is always different from the original one.
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+func (m *Map[Key, Value]) Insert(key Key, value Value) (newMap *Map[Key, Value], affected bool) {
+
+	newRoot, affected := m.root.insert(slot[Key, Value]{
+		hash:  m.hasher(key),
+		key:   key,
+		value: value,
+	}, 0)
+
+	newMap = m.withRoot(newRoot)
+	if affected {
+		newMap.cnt++
+	}
+	return newMap, affected
+}
+
+// `affected` is true, meaning that a real deletion occurred, `newMap` will be different from the original;
+// otherwise nothing happens, `newMap` is the original one.
+func (m *Map[Key, Value]) Delete(key Key) (newMap *Map[Key, Value], affected bool) {
+
+	newRoot, affected := m.root.delete(m.hasher(key), key, 0)
+	if !affected {
+		return m, false
+	}
+
+	if len(newRoot.slots) == 0 {
+		newRoot = nil
+	}
+
+	newMap = m.withRoot(newRoot)
+	newMap.cnt--
+	return newMap, true
+}
+
+func (m *Map[Key, Value]) withRoot(root *node[Key, Value]) *Map[Key, Value] {
+	mCopy := *m
+	mCopy.root = root
+	return &mCopy
+}
+
+// `locate()` returns the bit of `hash` at the level starting from `shift`, and the index of the slot it corresponds to.
+func (n *node[Key, Value]) locate(hash uint64, shift uint) (bit uint32, i int) {
+	bit = 1 << ((hash >> shift) & levelMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *node[Key, Value]) lookup(hash uint64, key Key, shift uint) *slot[Key, Value] {
+
+	for ; n != nil; shift += bitsPerLevel {
+
+		if shift >= hashBits {
+			for i := range n.slots {
+				if n.slots[i].key == key {
+					return &n.slots[i]
+				}
+			}
+			return nil
+		}
+
+		bit, i := n.locate(hash, shift)
+		if n.bitmap&bit == 0 {
+			return nil
+		}
+
+		s := &n.slots[i]
+		if s.child == nil {
+			if s.hash == hash && s.key == key {
+				return s
+			}
+			return nil
+		}
+		n = s.child
+	}
+
+	return nil
+}
+
+func (n *node[Key, Value]) traverse(yield func(Key, Value) bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	for i := range n.slots {
+		s := &n.slots[i]
+		if s.child != nil {
+			if !s.child.traverse(yield) {
+				return false
+			}
+		} else if !yield(s.key, s.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// `insert()` inserts `leaf` into the subtrie rooted at `n`, which may be nil, at the level starting from `shift`.
+func (n *node[Key, Value]) insert(leaf slot[Key, Value], shift uint) (newNode *node[Key, Value], affected bool) {
+
+	if n == nil {
+		n = &node[Key, Value]{}
+	}
+
+	if shift >= hashBits {
+		for i := range n.slots {
+			if n.slots[i].key == leaf.key {
+				return n.withSlot(i, leaf), false
+			}
+		}
+		return &node[Key, Value]{
+			slots: append(slices.Clip(n.slots), leaf),
+		}, true
+	}
+
+	bit, i := n.locate(leaf.hash, shift)
+	if n.bitmap&bit == 0 {
+		return &node[Key, Value]{
+			bitmap: n.bitmap | bit,
+			slots:  slices.Insert(slices.Clip(n.slots), i, leaf),
+		}, true
+	}
+
+	s := n.slots[i]
+	switch {
+	case s.child != nil:
+		newChild, affected := s.child.insert(leaf, shift+bitsPerLevel)
+		return n.withSlot(i, slot[Key, Value]{child: newChild}), affected
+	case s.hash == leaf.hash && s.key == leaf.key:
+		return n.withSlot(i, leaf), false
+	default:
+		return n.withSlot(i, slot[Key, Value]{child: merge(s, leaf, shift+bitsPerLevel)}), true
+	}
+}
+
+// `delete()` never inlines the subtrie rooted at `n` itself, which is left to its parent, or the map for the root.
+func (n *node[Key, Value]) delete(hash uint64, key Key, shift uint) (newNode *node[Key, Value], affected bool) {
+
+	if n == nil {
+		return nil, false
+	}
+
+	if shift >= hashBits {
+		for i := range n.slots {
+			if n.slots[i].key == key {
+				return &node[Key, Value]{
+					slots: slices.Delete(slices.Clone(n.slots), i, i+1),
+				}, true
+			}
+		}
+		return n, false
+	}
+
+	bit, i := n.locate(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	s := n.slots[i]
+	if s.child == nil {
+		if s.hash != hash || s.key != key {
+			return n, false
+		}
+		return &node[Key, Value]{
+			bitmap: n.bitmap &^ bit,
+			slots:  slices.Delete(slices.Clone(n.slots), i, i+1),
+		}, true
+	}
+
+	newChild, affected := s.child.delete(hash, key, shift+bitsPerLevel)
+	if !affected {
+		return n, false
+	}
+
+	// NOTE: A subtrie left with a single leaf is inlined, so that every subtrie holds at least 2 key-value pairs.
+	if len(newChild.slots) == 1 && newChild.slots[0].child == nil {
+		return n.withSlot(i, newChild.slots[0]), true
+	}
+	return n.withSlot(i, slot[Key, Value]{child: newChild}), true
+}
+
+func (n *node[Key, Value]) withSlot(i int, s slot[Key, Value]) *node[Key, Value] {
+	newSlots := slices.Clone(n.slots)
+	newSlots[i] = s
+	return &node[Key, Value]{
+		bitmap: n.bitmap,
+		slots:  newSlots,
+	}
+}
+
+// `merge()` returns a subtrie of leaves `l` and `r` with different keys at the level starting from `shift`.
+func merge[Key comparable, Value any](l slot[Key, Value], r slot[Key, Value], shift uint) *node[Key, Value] {
+
+	if shift >= hashBits {
+		return &node[Key, Value]{
+			slots: []slot[Key, Value]{l, r},
+		}
+	}
+
+	lBit := uint32(1) << ((l.hash >> shift) & levelMask)
+	rBit := uint32(1) << ((r.hash >> shift) & levelMask)
+
+	switch {
+	case lBit == rBit:
+		return &node[Key, Value]{
+			bitmap: lBit,
+			slots:  []slot[Key, Value]{{child: merge(l, r, shift+bitsPerLevel)}},
+		}
+	case lBit > rBit:
+		l, r = r, l
+	}
+
+	return &node[Key, Value]{
+		bitmap: lBit | rBit,
+		slots:  []slot[Key, Value]{l, r},
+	}
+}
@@ -0,0 +1,112 @@
+package immutable_hashmap
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+func TestMapInsert(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.insert(k, v).index(k) == v": func(xs map[string]int, k string, v int) bool {
+
+			m := FromGoMap(StringHasher(), xs)
+			_, has := m.Index(k)
+
+			newM, affected := m.Insert(k, v)
+			newV, newHas := newM.Index(k)
+			return affected == !has && newHas && newV == v && newM.Count() == len(xs)+btoi(affected)
+		},
+		"inserting should never affect the original map": func(xs map[string]int, ys map[string]int) bool {
+
+			m := FromGoMap(StringHasher(), xs)
+			for k, v := range ys {
+				m.Insert(k, v)
+			}
+
+			return reflect.DeepEqual(ToGoMap(m), xs)
+		},
+	})
+}
+
+func TestMapDelete(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.delete(k).index(k) should be absent": func(xs map[string]int, k string) bool {
+
+			m := FromGoMap(StringHasher(), xs)
+			_, has := m.Index(k)
+
+			newM, affected := m.Delete(k)
+			_, newHas := newM.Index(k)
+			return affected == has && !newHas && newM.Count() == len(xs)-btoi(affected) && (affected || newM == m)
+		},
+		"deleting every key makes m empty": func(xs map[string]int) bool {
+
+			m := FromGoMap(StringHasher(), xs)
+			for k := range xs {
+				m, _ = m.Delete(k)
+			}
+
+			return m.Empty() && m.Count() == 0 && m.root == nil
+		},
+	})
+}
+
+func TestMapCollision(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"maps with colliding hashes should behave like go maps": func(xs []int16, ys []int16) bool {
+
+			// NOTE: Only 4 distinct hashes, which differ in the top bits, so collisions happen at the deepest level.
+			m := New[int16, int](func(key int16) uint64 { return uint64(key&3) << 62 })
+			expected := map[int16]int{}
+
+			for i, x := range xs {
+				m, _ = m.Insert(x, i)
+				expected[x] = i
+			}
+			for _, y := range ys {
+				m, _ = m.Delete(y)
+				delete(expected, y)
+			}
+
+			return maps.Equal(ToGoMap(m), expected) && m.Count() == len(expected) && isCompact(m.root, true)
+		},
+	})
+}
+
+// `isCompact()` reports whether every subtrie below `n` holds at least 2 key-value pairs.
+func isCompact[Key comparable, Value any](n *node[Key, Value], isRoot bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	cnt := 0
+	for _, s := range n.slots {
+		if s.child == nil {
+			cnt++
+		} else if !isCompact(s.child, false) {
+			return false
+		} else {
+			cnt += 2
+		}
+	}
+	return isRoot || cnt >= 2
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestIntegerHasher(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"maps hashing integers should behave like go maps": func(xs map[int]string) bool {
+			return reflect.DeepEqual(ToGoMap(FromGoMap(IntegerHasher[int](), xs)), xs)
+		},
+	})
+}
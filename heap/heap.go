@@ -0,0 +1,167 @@
+// Implementation of Leftist Heaps
+//
+// References:
+// - Purely Functional Data Structures, Section 3.1: https://www.cs.cmu.edu/~rwh/students/okasaki.pdf
+package heap
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+type (
+	// The zero value of `Heap` makes nonsense.
+	Heap[T any] struct {
+		cmp  comparator.Comparator[T]
+		cnt  int
+		root *node[T]
+	}
+
+	// The rank of every left child is no less than that of its sibling,
+	// so the right spine, along which heaps are merged, is at most O(log n) long.
+	node[T any] struct {
+		left  *node[T]
+		right *node[T]
+		rank  int // length of the right spine
+		value T
+	}
+)
+
+func New[T any](cmp comparator.Comparator[T]) *Heap[T] {
+	return &Heap[T]{
+		cmp: cmp,
+	}
+}
+
+func FromValues[T any](cmp comparator.Comparator[T], values ...T) *Heap[T] {
+
+	if len(values) == 0 {
+		return New(cmp)
+	}
+
+	// NOTE: Merging singletons pairwise round by round costs O(n) in total.
+	nodes := make([]*node[T], 0, len(values))
+	for _, value := range values {
+		nodes = append(nodes, &node[T]{
+			rank:  1,
+			value: value,
+		})
+	}
+
+	for len(nodes) > 1 {
+		merged := nodes[:0]
+		for i := 0; i < len(nodes); i += 2 {
+			if i+1 < len(nodes) {
+				merged = append(merged, merge(cmp, nodes[i], nodes[i+1]))
+			} else {
+				merged = append(merged, nodes[i])
+			}
+		}
+		nodes = merged
+	}
+
+	return &Heap[T]{
+		cmp:  cmp,
+		cnt:  len(values),
+		root: nodes[0],
+	}
+}
+
+// CAUTION: Only invoke `FromSeq` with finite `seq`.
+func FromSeq[T any](cmp comparator.Comparator[T], seq iter.Seq[T]) *Heap[T] {
+	return FromValues(cmp, slices.Collect(seq)...)
+}
+
+func (h *Heap[T]) Empty() bool {
+	return h.root == nil
+}
+
+func (h *Heap[T]) Count() int {
+	return h.cnt
+}
+
+// Equal values are all kept.
+func (h *Heap[T]) Insert(value T) *Heap[T] {
+	return h.withRoot(merge(h.cmp, h.root, &node[T]{
+		rank:  1,
+		value: value,
+	}), h.cnt+1)
+}
+
+// `FindMin()` returns one of the least values.
+func (h *Heap[T]) FindMin() maybe.Maybe[T] {
+	if h.root == nil {
+		return maybe.Nothing[T]()
+	}
+	return maybe.Just(h.root.value)
+}
+
+// `DeleteMin()` returns a heap with the value returned by `FindMin()` removed, together with that value,
+// or nothing happens if `h` is empty.
+func (h *Heap[T]) DeleteMin() (newHeap *Heap[T], min maybe.Maybe[T]) {
+
+	if h.root == nil {
+		return h, maybe.Nothing[T]()
+	}
+
+	return h.withRoot(merge(h.cmp, h.root.left, h.root.right), h.cnt-1), maybe.Just(h.root.value)
+}
+
+// `Merge(other)` returns a heap of all values of both `h` and `other` in O(log n).
+// CAUTION: Only invoke `Merge` with heaps sharing the same comparator.
+func (h *Heap[T]) Merge(other *Heap[T]) *Heap[T] {
+	return h.withRoot(merge(h.cmp, h.root, other.root), h.cnt+other.cnt)
+}
+
+// `Drain()` returns an iterator of all values in ascending order, deleting the least one at each step.
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for rest, min := h.DeleteMin(); min.IsJust(); rest, min = rest.DeleteMin() {
+			if !yield(min.Value()) {
+				return
+			}
+		}
+	}
+}
+
+func (h *Heap[T]) withRoot(root *node[T], cnt int) *Heap[T] {
+	hCopy := *h
+	hCopy.cnt = cnt
+	hCopy.root = root
+	return &hCopy
+}
+
+func (n *node[T]) getRank() int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+// `merge()` walks down the right spines of `l` and `r`, swapping children wherever the leftist property is broken.
+func merge[T any](cmp comparator.Comparator[T], l *node[T], r *node[T]) *node[T] {
+
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case cmp(r.value, l.value) < 0:
+		l, r = r, l
+	}
+
+	left, right := l.left, merge(cmp, l.right, r)
+	if left.getRank() < right.getRank() {
+		left, right = right, left
+	}
+
+	return &node[T]{
+		left:  left,
+		right: right,
+		rank:  right.getRank() + 1,
+		value: l.value,
+	}
+}
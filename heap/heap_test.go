@@ -0,0 +1,59 @@
+package heap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+func TestHeap(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromValues(xs).drain() == sort(xs)": func(xs []int8) bool {
+
+			h := FromValues(comparator.OrderedComparator[int8], xs...)
+
+			return slices.Equal(slices.Collect(h.Drain()), slices.Sorted(slices.Values(xs))) &&
+				h.Count() == len(xs) &&
+				isLeftist(h.root)
+		},
+		"inserting one by one should be the same as fromValues(xs)": func(xs []int8) bool {
+
+			h := New(comparator.OrderedComparator[int8])
+			for _, x := range xs {
+				h = h.Insert(x)
+			}
+
+			return slices.Equal(slices.Collect(h.Drain()), slices.Collect(FromSeq(comparator.OrderedComparator[int8], slices.Values(xs)).Drain())) &&
+				isLeftist(h.root)
+		},
+		"h.findMin() == min(xs)": func(xs []int8) bool {
+
+			h := FromValues(comparator.OrderedComparator[int8], xs...)
+			newH, min := h.DeleteMin()
+
+			if len(xs) == 0 {
+				return h.FindMin().IsNothing() && min.IsNothing() && newH.Empty()
+			}
+			return h.FindMin().Value() == slices.Min(xs) && min.Value() == slices.Min(xs) && newH.Count() == len(xs)-1
+		},
+		"merge(xs, ys).drain() == sort(xs ++ ys)": func(xs []int8, ys []int8) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+			xh, yh := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			merged := xh.Merge(yh)
+			return slices.Equal(slices.Collect(merged.Drain()), slices.Sorted(slices.Values(append(slices.Clone(xs), ys...)))) &&
+				merged.Count() == len(xs)+len(ys) &&
+				isLeftist(merged.root) &&
+				slices.Equal(slices.Collect(xh.Drain()), slices.Sorted(slices.Values(xs)))
+		},
+	})
+}
+
+// `isLeftist()` reports whether all ranks under `n` are correct and no left child has a lower rank than its sibling.
+func isLeftist[T any](n *node[T]) bool {
+	return n == nil ||
+		(n.left.getRank() >= n.right.getRank() && n.rank == n.right.getRank()+1 && isLeftist(n.left) && isLeftist(n.right))
+}
@@ -0,0 +1,152 @@
+// Implementation of Interval Trees augmented with the highest end points of subtrees,
+// which are red-black trees of `immutable_rb_tree` summarizing high end points by their maximum.
+//
+// References:
+// - Introduction to Algorithms, Section 14.3
+package immutable_interval_tree
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/comparator"
+	immutable_func "github.com/freebirdljj/immutable/func"
+	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+type (
+	// `Interval` is the closed interval [`Lo`, `Hi`].
+	Interval[Point any] struct {
+		Lo Point
+		Hi Point
+	}
+
+	// The zero value of `IntervalMap` makes nonsense.
+	// Intervals are ordered by their low end points and then by their high end points.
+	IntervalMap[Point any, Value any] struct {
+		cmp        comparator.Comparator[Point]
+		summarized *immutable_rb_tree.Summarized[tuple.Pair[Interval[Point], Value], maxHi[Point]]
+	}
+
+	// `maxHi` is the highest high end point among intervals of a subtree, `ok` is false for the empty one.
+	maxHi[Point any] struct {
+		point Point
+		ok    bool
+	}
+)
+
+func NewIntervalMap[Point any, Value any](cmp comparator.Comparator[Point]) *IntervalMap[Point, Value] {
+	return &IntervalMap[Point, Value]{
+		cmp:        cmp,
+		summarized: immutable_rb_tree.NewSummarized(intervalPairComparator[Value](cmp), maxHiMonoid[Value](cmp)),
+	}
+}
+
+func (m *IntervalMap[Point, Value]) Empty() bool {
+	return m.summarized.Empty()
+}
+
+func (m *IntervalMap[Point, Value]) Count() int {
+	return m.summarized.Count()
+}
+
+func (m *IntervalMap[Point, Value]) Index(interval Interval[Point]) (value Value, has bool) {
+	pair := m.summarized.Lookup(tuple.Pair[Interval[Point], Value]{
+		First: interval,
+	})
+	if pair == nil {
+		return immutable_func.Zero[Value](), false
+	}
+	return pair.Second, true
+}
+
+// `All()` returns an iterator of all intervals with their values in ascending order.
+func (m *IntervalMap[Point, Value]) All() iter.Seq2[Interval[Point], Value] {
+	return func(yield func(Interval[Point], Value) bool) {
+		for pair := range m.summarized.All() {
+			if !yield(pair.First, pair.Second) {
+				return
+			}
+		}
+	}
+}
+
+// `Overlapping(lo, hi)` returns an iterator of all intervals overlapping [`lo`, `hi`] with their values in ascending order,
+// which costs O(min(n, k log n)) for k intervals found.
+// Subtrees ending before `lo` are skipped, and the iteration stops at the first interval starting after `hi`.
+// CAUTION: Only invoke `Overlapping(lo, hi)` with `lo` not greater than `hi`.
+func (m *IntervalMap[Point, Value]) Overlapping(lo Point, hi Point) iter.Seq2[Interval[Point], Value] {
+	return func(yield func(Interval[Point], Value) bool) {
+		endingFromLo := m.summarized.Search(func(summary maxHi[Point]) bool {
+			return summary.ok && m.cmp(summary.point, lo) >= 0
+		})
+		for pair := range endingFromLo {
+			if m.cmp(pair.First.Lo, hi) > 0 || !yield(pair.First, pair.Second) {
+				return
+			}
+		}
+	}
+}
+
+// `Stabbing(point)` returns an iterator of all intervals containing `point` with their values in ascending order.
+func (m *IntervalMap[Point, Value]) Stabbing(point Point) iter.Seq2[Interval[Point], Value] {
+	return m.Overlapping(point, point)
+}
+
+// `newMap` returned by `Insert()` is always different from the original one.
+// `affected` is true, meaning an actual insertion occurred; otherwise, the value of `interval` is replaced.
+// CAUTION: Only invoke `Insert` with `interval` whose `Lo` is not greater than its `Hi`.
+func (m *IntervalMap[Point, Value]) Insert(interval Interval[Point], value Value) (newMap *IntervalMap[Point, Value], affected bool) {
+	newSummarized, affected := m.summarized.Insert(tuple.Pair[Interval[Point], Value]{
+		First:  interval,
+		Second: value,
+	})
+	return m.withSummarized(newSummarized), affected
+}
+
+// `affected` is true, meaning that a real deletion occurred, `newMap` will be different from the original;
+// otherwise nothing happens, `newMap` is the original one.
+func (m *IntervalMap[Point, Value]) Delete(interval Interval[Point]) (newMap *IntervalMap[Point, Value], affected bool) {
+
+	newSummarized, affected := m.summarized.Delete(tuple.Pair[Interval[Point], Value]{
+		First: interval,
+	})
+	if !affected {
+		return m, false
+	}
+
+	return m.withSummarized(newSummarized), true
+}
+
+func (m *IntervalMap[Point, Value]) withSummarized(summarized *immutable_rb_tree.Summarized[tuple.Pair[Interval[Point], Value], maxHi[Point]]) *IntervalMap[Point, Value] {
+	return &IntervalMap[Point, Value]{
+		cmp:        m.cmp,
+		summarized: summarized,
+	}
+}
+
+func intervalPairComparator[Value any, Point any](cmp comparator.Comparator[Point]) comparator.Comparator[tuple.Pair[Interval[Point], Value]] {
+	return func(l tuple.Pair[Interval[Point], Value], r tuple.Pair[Interval[Point], Value]) int {
+		if c := cmp(l.First.Lo, r.First.Lo); c != 0 {
+			return c
+		}
+		return cmp(l.First.Hi, r.First.Hi)
+	}
+}
+
+func maxHiMonoid[Value any, Point any](cmp comparator.Comparator[Point]) immutable_rb_tree.Monoid[tuple.Pair[Interval[Point], Value], maxHi[Point]] {
+	return immutable_rb_tree.Monoid[tuple.Pair[Interval[Point], Value], maxHi[Point]]{
+		Combine: func(l maxHi[Point], r maxHi[Point]) maxHi[Point] {
+			if !l.ok || (r.ok && cmp(r.point, l.point) > 0) {
+				return r
+			}
+			return l
+		},
+		Measure: func(pair tuple.Pair[Interval[Point], Value]) maxHi[Point] {
+			return maxHi[Point]{
+				point: pair.First.Hi,
+				ok:    true,
+			}
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package immutable_interval_tree
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/comparator"
+)
+
+type (
+	// The zero value of `IntervalTree` makes nonsense.
+	IntervalTree[Point any] IntervalMap[Point, struct{}]
+)
+
+func New[Point any](cmp comparator.Comparator[Point]) *IntervalTree[Point] {
+	return (*IntervalTree[Point])(NewIntervalMap[Point, struct{}](cmp))
+}
+
+// Among equal intervals, the last one is preserved.
+func FromIntervals[Point any](cmp comparator.Comparator[Point], intervals ...Interval[Point]) *IntervalTree[Point] {
+	t := New(cmp)
+	for _, interval := range intervals {
+		t, _ = t.Insert(interval)
+	}
+	return t
+}
+
+func (t *IntervalTree[Point]) Empty() bool {
+	return t.intervalMap().Empty()
+}
+
+func (t *IntervalTree[Point]) Count() int {
+	return t.intervalMap().Count()
+}
+
+func (t *IntervalTree[Point]) Has(interval Interval[Point]) bool {
+	_, has := t.intervalMap().Index(interval)
+	return has
+}
+
+// `All()` returns an iterator of all intervals in ascending order.
+func (t *IntervalTree[Point]) All() iter.Seq[Interval[Point]] {
+	return keys(t.intervalMap().All())
+}
+
+// `Overlapping(lo, hi)` returns an iterator of all intervals overlapping [`lo`, `hi`] in ascending order,
+// which costs O(min(n, k log n)) for k intervals found.
+// CAUTION: Only invoke `Overlapping(lo, hi)` with `lo` not greater than `hi`.
+func (t *IntervalTree[Point]) Overlapping(lo Point, hi Point) iter.Seq[Interval[Point]] {
+	return keys(t.intervalMap().Overlapping(lo, hi))
+}
+
+// `Stabbing(point)` returns an iterator of all intervals containing `point` in ascending order.
+func (t *IntervalTree[Point]) Stabbing(point Point) iter.Seq[Interval[Point]] {
+	return keys(t.intervalMap().Stabbing(point))
+}
+
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+// CAUTION: Only invoke `Insert` with `interval` whose `Lo` is not greater than its `Hi`.
+func (t *IntervalTree[Point]) Insert(interval Interval[Point]) (newTree *IntervalTree[Point], affected bool) {
+	newMap, affected := t.intervalMap().Insert(interval, struct{}{})
+	return (*IntervalTree[Point])(newMap), affected
+}
+
+// `affected` is true, meaning that a real deletion occurred, `newTree` will be different from the original;
+// otherwise nothing happens, `newTree` is the original one.
+func (t *IntervalTree[Point]) Delete(interval Interval[Point]) (newTree *IntervalTree[Point], affected bool) {
+	newMap, affected := t.intervalMap().Delete(interval)
+	return (*IntervalTree[Point])(newMap), affected
+}
+
+func (t *IntervalTree[Point]) intervalMap() *IntervalMap[Point, struct{}] {
+	return (*IntervalMap[Point, struct{}])(t)
+}
+
+func keys[Point any](seq iter.Seq2[Interval[Point], struct{}]) iter.Seq[Interval[Point]] {
+	return func(yield func(Interval[Point]) bool) {
+		for interval := range seq {
+			if !yield(interval) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package immutable_interval_tree
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+// `intervalsOf()` turns every pair of end points into a valid interval.
+func intervalsOf(endPoints [][2]int8) []Interval[int8] {
+	intervals := make([]Interval[int8], 0, len(endPoints))
+	for _, e := range endPoints {
+		intervals = append(intervals, Interval[int8]{Lo: min(e[0], e[1]), Hi: max(e[0], e[1])})
+	}
+	return intervals
+}
+
+// `overlapping()` is a brute-force reference implementation of `Overlapping()`.
+func overlapping(t *IntervalTree[int8], lo int8, hi int8) []Interval[int8] {
+	res := []Interval[int8](nil)
+	for interval := range t.All() {
+		if interval.Lo <= hi && lo <= interval.Hi {
+			res = append(res, interval)
+		}
+	}
+	return res
+}
+
+func TestIntervalTreeOverlapping(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"t.overlapping(lo, hi) should yield exactly all intervals overlapping [lo, hi]": func(endPoints [][2]int8, deleted [][2]int8, x int8, y int8) bool {
+
+			tr := FromIntervals(comparator.OrderedComparator[int8], intervalsOf(endPoints)...)
+			for _, interval := range intervalsOf(deleted) {
+				tr, _ = tr.Delete(interval)
+			}
+
+			lo, hi := min(x, y), max(x, y)
+			return slices.Equal(slices.Collect(tr.Overlapping(lo, hi)), overlapping(tr, lo, hi)) &&
+				slices.Equal(slices.Collect(tr.Stabbing(x)), overlapping(tr, x, x))
+		},
+		"t.all() should be sorted and deduplicated intervals": func(endPoints [][2]int8) bool {
+
+			intervals := intervalsOf(endPoints)
+			tr := FromIntervals(comparator.OrderedComparator[int8], intervals...)
+
+			expected := slices.Compact(slices.SortedFunc(slices.Values(intervals), func(l Interval[int8], r Interval[int8]) int {
+				if l.Lo != r.Lo {
+					return int(l.Lo) - int(r.Lo)
+				}
+				return int(l.Hi) - int(r.Hi)
+			}))
+			return slices.Equal(slices.Collect(tr.All()), expected) && tr.Count() == len(expected)
+		},
+	})
+}
+
+func TestIntervalMap(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.insert(i, v).index(i) == v and m is never affected": func(endPoints [][2]int8, e [2]int8, v int) bool {
+
+			m := NewIntervalMap[int8, int](comparator.OrderedComparator[int8])
+			for i, interval := range intervalsOf(endPoints) {
+				m, _ = m.Insert(interval, i)
+			}
+
+			interval := intervalsOf([][2]int8{e})[0]
+			oldV, has := m.Index(interval)
+
+			newM, affected := m.Insert(interval, v)
+			newV, newHas := newM.Index(interval)
+			stillV, stillHas := m.Index(interval)
+
+			found := false
+			for i, value := range newM.Stabbing(interval.Lo) {
+				found = found || (i == interval && value == v)
+			}
+
+			return affected == !has && newHas && newV == v && stillHas == has && stillV == oldV && found
+		},
+	})
+}
+
+// `isBalanced()` reports whether `t` is a valid red-black tree summarizing the highest high end point.
+func isBalanced(t *IntervalTree[int8]) bool {
+
+	summarized := t.intervalMap().summarized
+
+	expected := maxHi[int8]{}
+	for interval := range t.All() {
+		if !expected.ok || interval.Hi > expected.point {
+			expected = maxHi[int8]{point: interval.Hi, ok: true}
+		}
+	}
+
+	return summarized.Validate() == nil && summarized.Summary() == expected
+}
+
+func TestIntervalTreeBalance(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"t should stay balanced after insertions and deletions": func(endPoints [][2]int8) bool {
+
+			intervals := intervalsOf(endPoints)
+			tr := FromIntervals(comparator.OrderedComparator[int8], intervals...)
+			for i := 0; i < len(intervals); i += 2 {
+				tr, _ = tr.Delete(intervals[i])
+				if !isBalanced(tr) {
+					return false
+				}
+			}
+
+			return isBalanced(tr) && tr.Count() == len(slices.Collect(tr.All()))
+		},
+	})
+}
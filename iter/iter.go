@@ -3,9 +3,16 @@ package iter
 import (
 	"iter"
 
+	"github.com/freebirdljj/immutable/maybe"
 	"github.com/freebirdljj/immutable/tuple"
 )
 
+type (
+	integer interface {
+		~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+	}
+)
+
 func SeqFromSeq2[K any, V any](seq2 iter.Seq2[K, V]) iter.Seq[tuple.KeyValuePair[K, V]] {
 	return func(yield func(tuple.KeyValuePair[K, V]) bool) {
 		seq2(func(key K, value V) bool {
@@ -37,7 +44,7 @@ func Singleton[V any](v V) iter.Seq[V] {
 
 func Repeat[V any](v V) iter.Seq[V] {
 	return func(yield func(V) bool) {
-		for !yield(v) {
+		for yield(v) {
 		}
 	}
 }
@@ -106,3 +113,290 @@ func Drop[V any](seq iter.Seq[V], n int) iter.Seq[V] {
 		}
 	}
 }
+
+func Filter[V any](seq iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			if predicate(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func FlatMap[V1 any, V2 any](seq iter.Seq[V1], f func(V1) iter.Seq[V2]) iter.Seq[V2] {
+	return Concat(Map(seq, f))
+}
+
+func TakeWhile[V any](seq iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			if !predicate(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func DropWhile[V any](seq iter.Seq[V], predicate func(V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		dropping := true
+		for v := range seq {
+			dropping = dropping && predicate(v)
+			if !dropping && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// `Scan(seq, init, f)` is like `Fold(seq, init, f)`, but yields every intermediate accumulation except `init`.
+func Scan[V1 any, V2 any](seq iter.Seq[V1], init V2, f func(acc V2, v V1) V2) iter.Seq[V2] {
+	return func(yield func(V2) bool) {
+		acc := init
+		for v := range seq {
+			acc = f(acc, v)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// CAUTION: Only invoke `Fold` with finite `seq`.
+func Fold[V1 any, V2 any](seq iter.Seq[V1], init V2, f func(acc V2, v V1) V2) V2 {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// `Reduce(seq, f)` is like `Fold(seq, init, f)` with the first value as `init`, which returns nothing for empty `seq`.
+// CAUTION: Only invoke `Reduce` with finite `seq`.
+func Reduce[V any](seq iter.Seq[V], f func(acc V, v V) V) maybe.Maybe[V] {
+	acc := maybe.Nothing[V]()
+	for v := range seq {
+		if acc.IsNothing() {
+			acc = maybe.Just(v)
+		} else {
+			acc = maybe.Just(f(acc.Value(), v))
+		}
+	}
+	return acc
+}
+
+// `Chunk(seq, n)` splits `seq` into consecutive chunks of `n` values, except that the last one may be shorter.
+// CAUTION: `n` must be positive.
+func Chunk[V any](seq iter.Seq[V], n int) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		chunk := make([]V, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]V, 0, n)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// `Window(seq, n)` yields every `n` consecutive values of `seq`, sliding one value at a time.
+// CAUTION: `n` must be positive.
+func Window[V any](seq iter.Seq[V], n int) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		window := []V(nil)
+		for v := range seq {
+			if len(window) == n {
+				window = window[1:]
+			}
+			// NOTE: Always copy the window, since yielded windows may be retained.
+			window = append(append(make([]V, 0, n), window...), v)
+			if len(window) == n && !yield(window) {
+				return
+			}
+		}
+	}
+}
+
+func Enumerate[V any](seq iter.Seq[V]) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// `Dedup(seq)` collapses consecutive equal values into one.
+func Dedup[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return DedupFunc(seq, func(l V, r V) bool { return l == r })
+}
+
+// `DedupFunc(seq, eq)` is like `Dedup(seq)`, but values are compared by `eq`.
+func DedupFunc[V any](seq iter.Seq[V], eq func(V, V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		prev := maybe.Nothing[V]()
+		for v := range seq {
+			if prev.IsJust() && eq(prev.Value(), v) {
+				continue
+			}
+			prev = maybe.Just(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// `Interleave(seqs...)` takes values from `seqs` in turn, skipping exhausted ones.
+func Interleave[V any](seqs ...iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+
+		nexts := make([]func() (V, bool), 0, len(seqs))
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+
+		for len(nexts) > 0 {
+			alive := nexts[:0]
+			for _, next := range nexts {
+				v, ok := next()
+				if !ok {
+					continue
+				}
+				if !yield(v) {
+					return
+				}
+				alive = append(alive, next)
+			}
+			nexts = alive
+		}
+	}
+}
+
+// `Unfold(seed, f)` yields values built from `seed` until `f` returns nothing.
+func Unfold[V any, S any](seed S, f func(S) maybe.Maybe[tuple.Pair[V, S]]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for step := f(seed); step.IsJust(); step = f(step.Value().Second) {
+			if !yield(step.Value().First) {
+				return
+			}
+		}
+	}
+}
+
+// `Iterate(v, f)` yields `v, f(v), f(f(v)), ...` infinitely.
+func Iterate[V any](v V, f func(V) V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for ; yield(v); v = f(v) {
+		}
+	}
+}
+
+// `Range(lo, hi)` yields integers in [lo, hi) in ascending order.
+func Range[V integer](lo V, hi V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := lo; v < hi; v++ {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func Find[V any](seq iter.Seq[V], predicate func(V) bool) maybe.Maybe[V] {
+	for v := range seq {
+		if predicate(v) {
+			return maybe.Just(v)
+		}
+	}
+	return maybe.Nothing[V]()
+}
+
+func Zip[V1 any, V2 any](seq1 iter.Seq[V1], seq2 iter.Seq[V2]) iter.Seq[tuple.Pair[V1, V2]] {
+	return ZipWith(seq1, seq2, func(v1 V1, v2 V2) tuple.Pair[V1, V2] {
+		return tuple.Pair[V1, V2]{First: v1, Second: v2}
+	})
+}
+
+func ZipWith[V1 any, V2 any, V3 any](seq1 iter.Seq[V1], seq2 iter.Seq[V2], f func(V1, V2) V3) iter.Seq[V3] {
+	return func(yield func(V3) bool) {
+
+		next2, stop2 := iter.Pull(seq2)
+		defer stop2()
+
+		for v1 := range seq1 {
+			v2, ok := next2()
+			if !ok || !yield(f(v1, v2)) {
+				return
+			}
+		}
+	}
+}
+
+func Zip3[V1 any, V2 any, V3 any](seq1 iter.Seq[V1], seq2 iter.Seq[V2], seq3 iter.Seq[V3]) iter.Seq[tuple.Triple[V1, V2, V3]] {
+	return func(yield func(tuple.Triple[V1, V2, V3]) bool) {
+
+		next2, stop2 := iter.Pull(seq2)
+		defer stop2()
+		next3, stop3 := iter.Pull(seq3)
+		defer stop3()
+
+		for v1 := range seq1 {
+			v2, ok2 := next2()
+			if !ok2 {
+				return
+			}
+			v3, ok3 := next3()
+			if !ok3 || !yield(tuple.Triple[V1, V2, V3]{First: v1, Second: v2, Third: v3}) {
+				return
+			}
+		}
+	}
+}
+
+// `ZipLongest(seq1, seq2)` is like `Zip(seq1, seq2)`, but pads the shorter one with nothing.
+func ZipLongest[V1 any, V2 any](seq1 iter.Seq[V1], seq2 iter.Seq[V2]) iter.Seq[tuple.Pair[maybe.Maybe[V1], maybe.Maybe[V2]]] {
+	return func(yield func(tuple.Pair[maybe.Maybe[V1], maybe.Maybe[V2]]) bool) {
+
+		next1, stop1 := iter.Pull(seq1)
+		defer stop1()
+		next2, stop2 := iter.Pull(seq2)
+		defer stop2()
+
+		for {
+			v1, ok1 := next1()
+			v2, ok2 := next2()
+			if !ok1 && !ok2 {
+				return
+			}
+			pair := tuple.Pair[maybe.Maybe[V1], maybe.Maybe[V2]]{}
+			if ok1 {
+				pair.First = maybe.Just(v1)
+			}
+			if ok2 {
+				pair.Second = maybe.Just(v2)
+			}
+			if !yield(pair) {
+				return
+			}
+		}
+	}
+}
+
+// CAUTION: `seq` can't be a single-use iterator if both results are used.
+func Unzip[V1 any, V2 any](seq iter.Seq[tuple.Pair[V1, V2]]) (seq1 iter.Seq[V1], seq2 iter.Seq[V2]) {
+	return Map(seq, func(pair tuple.Pair[V1, V2]) V1 { return pair.First }),
+		Map(seq, func(pair tuple.Pair[V1, V2]) V2 { return pair.Second })
+}
@@ -2,11 +2,14 @@ package iter
 
 import (
 	"iter"
+	"reflect"
 	"slices"
 	"strconv"
 	"testing"
 
 	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
 )
 
 func TestEmpty(t *testing.T) {
@@ -31,6 +34,20 @@ func TestSingleton(t *testing.T) {
 	})
 }
 
+func TestRepeat(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Take(Repeat(x), n) == [x] * n": func(x int, n uint8) bool {
+
+			expected := []int(nil)
+			for range n {
+				expected = append(expected, x)
+			}
+
+			return slices.Equal(slices.Collect(Take(Repeat(x), int(n))), expected)
+		},
+	})
+}
+
 func TestCycle(t *testing.T) {
 	quick.CheckProperties(t, map[string]any{
 		"Take(Cycle(xs), 2 * len(xs)) == xs ++ xs": func(xs []int, last int) bool {
@@ -140,3 +157,390 @@ func TestDrop(t *testing.T) {
 		},
 	})
 }
+
+func TestZip(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Zip(xs, ys) terminates with the shorter one": func(xs []int, ys []string) bool {
+
+			expected := []tuple.Pair[int, string](nil)
+			for i := range min(len(xs), len(ys)) {
+				expected = append(expected, tuple.Pair[int, string]{First: xs[i], Second: ys[i]})
+			}
+
+			return slices.Equal(slices.Collect(Zip(slices.Values(xs), slices.Values(ys))), expected)
+		},
+		"Zip(Cycle(xs), ys) terminates with ys": func(xs []int, last int, ys []int) bool {
+			nonemptySlice := append(xs, last)
+			return len(slices.Collect(Zip(Cycle(slices.Values(nonemptySlice)), slices.Values(ys)))) == len(ys)
+		},
+		"ZipWith(xs, ys, f) == Map(Zip(xs, ys), f)": func(xs []int, ys []int) bool {
+			sub := func(x int, y int) int { return x - y }
+			return slices.Equal(
+				slices.Collect(ZipWith(slices.Values(xs), slices.Values(ys), sub)),
+				slices.Collect(Map(Zip(slices.Values(xs), slices.Values(ys)), func(xy tuple.Pair[int, int]) int { return sub(xy.First, xy.Second) })),
+			)
+		},
+		"Zip3(xs, ys, zs) terminates with the shortest one": func(xs []int, ys []string, zs []bool) bool {
+
+			expected := []tuple.Triple[int, string, bool](nil)
+			for i := range min(len(xs), len(ys), len(zs)) {
+				expected = append(expected, tuple.Triple[int, string, bool]{First: xs[i], Second: ys[i], Third: zs[i]})
+			}
+
+			return slices.Equal(slices.Collect(Zip3(slices.Values(xs), slices.Values(ys), slices.Values(zs))), expected)
+		},
+	})
+}
+
+func TestZipLongest(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"ZipLongest(xs, ys) pads the shorter one with nothing": func(xs []int, ys []int) bool {
+
+			expected := []tuple.Pair[maybe.Maybe[int], maybe.Maybe[int]](nil)
+			for i := range max(len(xs), len(ys)) {
+				pair := tuple.Pair[maybe.Maybe[int], maybe.Maybe[int]]{}
+				if i < len(xs) {
+					pair.First = maybe.Just(xs[i])
+				}
+				if i < len(ys) {
+					pair.Second = maybe.Just(ys[i])
+				}
+				expected = append(expected, pair)
+			}
+
+			return reflect.DeepEqual(slices.Collect(ZipLongest(slices.Values(xs), slices.Values(ys))), expected)
+		},
+	})
+}
+
+func TestUnzip(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Unzip(Zip(xs, ys)) == (xs, ys) if len(xs) == len(ys)": func(xys map[int]string) bool {
+
+			xs, ys := []int(nil), []string(nil)
+			for x, y := range xys {
+				xs, ys = append(xs, x), append(ys, y)
+			}
+
+			seq1, seq2 := Unzip(Zip(slices.Values(xs), slices.Values(ys)))
+			return slices.Equal(slices.Collect(seq1), xs) && slices.Equal(slices.Collect(seq2), ys)
+		},
+	})
+}
+
+func TestFilter(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Filter(xs, p) == [x for x in xs if p(x)]": func(xs []int) bool {
+
+			even := func(x int) bool { return x%2 == 0 }
+
+			expected := []int(nil)
+			for _, x := range xs {
+				if even(x) {
+					expected = append(expected, x)
+				}
+			}
+
+			return slices.Equal(slices.Collect(Filter(slices.Values(xs), even)), expected)
+		},
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"FlatMap(xs, f) == Concat(Map(xs, f))": func(xs []uint8) bool {
+			f := func(x uint8) iter.Seq[uint8] { return Range(0, x%4) }
+			return slices.Equal(
+				slices.Collect(FlatMap(slices.Values(xs), f)),
+				slices.Collect(Concat(Map(slices.Values(xs), f))),
+			)
+		},
+	})
+}
+
+func TestTakeWhileAndDropWhile(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"TakeWhile(xs, p) ++ DropWhile(xs, p) == xs": func(xs []int) bool {
+			even := func(x int) bool { return x%2 == 0 }
+			return slices.Equal(
+				append(slices.Collect(TakeWhile(slices.Values(xs), even)), slices.Collect(DropWhile(slices.Values(xs), even))...),
+				xs,
+			)
+		},
+		"TakeWhile(xs, p) all satisfy p and DropWhile(xs, p) starts with a value not satisfying p": func(xs []int) bool {
+
+			even := func(x int) bool { return x%2 == 0 }
+			dropped := slices.Collect(DropWhile(slices.Values(xs), even))
+
+			return Find(TakeWhile(slices.Values(xs), even), func(x int) bool { return !even(x) }).IsNothing() &&
+				(len(dropped) == 0 || !even(dropped[0]))
+		},
+		"TakeWhile(Iterate(0, +1), < n) == Range(0, n)": func(n uint8) bool {
+			return slices.Equal(
+				slices.Collect(TakeWhile(Iterate(0, func(x int) int { return x + 1 }), func(x int) bool { return x < int(n) })),
+				slices.Collect(Range(0, int(n))),
+			)
+		},
+	})
+}
+
+func TestScanAndFold(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"last(Scan(xs, init, f)) == Fold(xs, init, f)": func(xs []int, init int) bool {
+			add := func(acc int, x int) int { return acc + x }
+			scanned := slices.Collect(Scan(slices.Values(xs), init, add))
+			return len(scanned) == len(xs) && (len(xs) == 0 || scanned[len(scanned)-1] == Fold(slices.Values(xs), init, add))
+		},
+		"Reduce(xs, f) == Fold(xs[1:], xs[0], f)": func(xs []int) bool {
+			add := func(acc int, x int) int { return acc + x }
+			reduced := Reduce(slices.Values(xs), add)
+			if len(xs) == 0 {
+				return reduced.IsNothing()
+			}
+			return reduced.IsJust() && reduced.Value() == Fold(slices.Values(xs[1:]), xs[0], add)
+		},
+	})
+}
+
+func TestChunk(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Concat(Chunk(xs, n)) == xs and only the last chunk may be shorter": func(xs []int, n uint8) bool {
+
+			size := int(n%8) + 1
+			chunks := slices.Collect(Chunk(slices.Values(xs), size))
+
+			for i, chunk := range chunks {
+				if len(chunk) == 0 || len(chunk) > size || (i < len(chunks)-1 && len(chunk) != size) {
+					return false
+				}
+			}
+			return slices.Equal(slices.Concat(chunks...), xs)
+		},
+	})
+}
+
+func TestWindow(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Window(xs, n)[i] == xs[i:i+n]": func(xs []int, n uint8) bool {
+
+			size := int(n%8) + 1
+			windows := slices.Collect(Window(slices.Values(xs), size))
+
+			for i, window := range windows {
+				if !slices.Equal(window, xs[i:i+size]) {
+					return false
+				}
+			}
+			return len(windows) == max(len(xs)-size+1, 0)
+		},
+	})
+}
+
+func TestEnumerate(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Enumerate(xs) == All(xs)": func(xs []int) bool {
+			for i, x := range Enumerate(slices.Values(xs)) {
+				if xs[i] != x {
+					return false
+				}
+			}
+			return true
+		},
+	})
+}
+
+func TestDedup(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Dedup(xs) == slices.Compact(xs)": func(xs []uint8) bool {
+			for i := range xs {
+				xs[i] %= 4
+			}
+			return slices.Equal(slices.Collect(Dedup(slices.Values(xs))), slices.Compact(slices.Clone(xs)))
+		},
+	})
+}
+
+func TestInterleave(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Interleave(xs, ys) takes values in turn": func(xs []int, ys []int) bool {
+
+			expected := []int(nil)
+			for i := range max(len(xs), len(ys)) {
+				if i < len(xs) {
+					expected = append(expected, xs[i])
+				}
+				if i < len(ys) {
+					expected = append(expected, ys[i])
+				}
+			}
+
+			return slices.Equal(slices.Collect(Interleave(slices.Values(xs), slices.Values(ys))), expected)
+		},
+		"Take(Interleave(Repeat(x), Repeat(y)), 2 * n) == [x, y] * n": func(x int, y int, n uint8) bool {
+
+			expected := []int(nil)
+			for range n {
+				expected = append(expected, x, y)
+			}
+
+			return slices.Equal(slices.Collect(Take(Interleave(Repeat(x), Repeat(y)), 2*int(n))), expected)
+		},
+	})
+}
+
+func TestUnfold(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Unfold(n, countdown) == reverse(Range(0, n))": func(n uint8) bool {
+
+			countdown := func(n int) maybe.Maybe[tuple.Pair[int, int]] {
+				if n == 0 {
+					return maybe.Nothing[tuple.Pair[int, int]]()
+				}
+				return maybe.Just(tuple.Pair[int, int]{First: n - 1, Second: n - 1})
+			}
+
+			expected := slices.Collect(Range(0, int(n)))
+			slices.Reverse(expected)
+			return slices.Equal(slices.Collect(Unfold(int(n), countdown)), expected)
+		},
+	})
+}
+
+func TestRange(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"len(Range(lo, hi)) == max(hi - lo, 0)": func(lo int8, hi int8) bool {
+			return len(slices.Collect(Range(lo, hi))) == max(int(hi)-int(lo), 0)
+		},
+	})
+}
+
+func TestFind(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Find(xs, p) == first x in xs satisfying p": func(xs []int) bool {
+
+			even := func(x int) bool { return x%2 == 0 }
+			found := Find(slices.Values(xs), even)
+
+			i := slices.IndexFunc(xs, even)
+			return (i < 0 && found.IsNothing()) || (i >= 0 && found.IsJust() && found.Value() == xs[i])
+		},
+		"Find(Iterate(x, +1), p) terminates": func(x int) bool {
+			return Find(Iterate(x, func(x int) int { return x + 1 }), func(x int) bool { return x%7 == 0 }).IsJust()
+		},
+	})
+}
+
+func TestSeq2Counterparts(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"Filter2(seq2, p) == Seq2FromSeq(Filter(SeqFromSeq2(seq2), p))": func(xs []int) bool {
+			evenIndex := func(i int, _ int) bool { return i%2 == 0 }
+			return slices.Equal(
+				slices.Collect(SeqFromSeq2(Filter2(slices.All(xs), evenIndex))),
+				slices.Collect(Filter(SeqFromSeq2(slices.All(xs)), func(kvPair tuple.KeyValuePair[int, int]) bool {
+					return evenIndex(kvPair.Key, kvPair.Value)
+				})),
+			)
+		},
+		"TakeWhile2(seq2, p) ++ DropWhile2(seq2, p) == seq2": func(xs []int) bool {
+			even := func(_ int, x int) bool { return x%2 == 0 }
+			return slices.Equal(
+				append(slices.Collect(SeqFromSeq2(TakeWhile2(slices.All(xs), even))), slices.Collect(SeqFromSeq2(DropWhile2(slices.All(xs), even)))...),
+				slices.Collect(SeqFromSeq2(slices.All(xs))),
+			)
+		},
+		"FlatMap2(seq2, f) flattens seq2s returned by f": func(xs []int) bool {
+			duplicate := func(i int, x int) iter.Seq2[int, int] {
+				return slices.All([]int{x, x})
+			}
+			return len(slices.Collect(SeqFromSeq2(FlatMap2(slices.All(xs), duplicate)))) == 2*len(xs)
+		},
+		"Fold2(All(xs), 0, acc + i * x) == sum of i * xs[i]": func(xs []int) bool {
+
+			expected := 0
+			for i, x := range xs {
+				expected += i * x
+			}
+
+			return Fold2(slices.All(xs), 0, func(acc int, i int, x int) int { return acc + i*x }) == expected
+		},
+		"Find2(All(xs), p) == first (i, x) satisfying p": func(xs []int) bool {
+
+			even := func(_ int, x int) bool { return x%2 == 0 }
+			found := Find2(slices.All(xs), even)
+
+			i := slices.IndexFunc(xs, func(x int) bool { return x%2 == 0 })
+			return (i < 0 && found.IsNothing()) || (i >= 0 && found.IsJust() && found.Value().Key == i)
+		},
+		"Interleave2(All(xs), All(ys)) == Interleave of pairs": func(xs []int, ys []int) bool {
+			return slices.Equal(
+				slices.Collect(SeqFromSeq2(Interleave2(slices.All(xs), slices.All(ys)))),
+				slices.Collect(Interleave(SeqFromSeq2(slices.All(xs)), SeqFromSeq2(slices.All(ys)))),
+			)
+		},
+		"Scan2(All(xs), 0, acc + x) == Scan(xs, 0, acc + x)": func(xs []int) bool {
+			return slices.Equal(
+				slices.Collect(Scan2(slices.All(xs), 0, func(acc int, _ int, x int) int { return acc + x })),
+				slices.Collect(Scan(slices.Values(xs), 0, func(acc int, x int) int { return acc + x })),
+			)
+		},
+		"Reduce2(All(xs), max index with sum) == (len(xs) - 1, sum of xs)": func(xs []int) bool {
+
+			reduced := Reduce2(slices.All(xs), func(_ int, acc int, i int, x int) (int, int) { return i, acc + x })
+
+			sum := 0
+			for _, x := range xs {
+				sum += x
+			}
+			return (len(xs) == 0 && reduced.IsNothing()) ||
+				(len(xs) > 0 && reduced.IsJust() && reduced.Value() == tuple.KeyValuePair[int, int]{Key: len(xs) - 1, Value: sum})
+		},
+		"Chunk2(seq2, n) and Window2(seq2, n) == Chunk and Window of pairs": func(xs []int, n uint8) bool {
+			size := int(n%8) + 1
+			pairs := slices.Collect(SeqFromSeq2(slices.All(xs)))
+			return reflect.DeepEqual(slices.Collect(Chunk2(slices.All(xs), size)), slices.Collect(Chunk(slices.Values(pairs), size))) &&
+				reflect.DeepEqual(slices.Collect(Window2(slices.All(xs), size)), slices.Collect(Window(slices.Values(pairs), size)))
+		},
+		"Dedup2(seq2) == Dedup of pairs": func(xs []bool) bool {
+			seq2 := Seq2FromSeq(Map(slices.Values(xs), func(x bool) tuple.KeyValuePair[bool, int] {
+				return tuple.KeyValuePair[bool, int]{Key: x}
+			}))
+			return slices.Equal(
+				slices.Collect(SeqFromSeq2(Dedup2(seq2))),
+				slices.Collect(Dedup(SeqFromSeq2(seq2))),
+			)
+		},
+		"DedupFunc2(All(xs), same value) keeps the first index of every run": func(xs []bool) bool {
+			sameValue := func(_ int, l bool, _ int, r bool) bool { return l == r }
+			for i, x := range DedupFunc2(slices.All(xs), sameValue) {
+				if xs[i] != x || (i > 0 && xs[i-1] == x) {
+					return false
+				}
+			}
+			return true
+		},
+		"Unfold2(0, i < n ? (i, i * i, i + 1)) == (i, i * i) for i in [0, n)": func(n uint8) bool {
+
+			squares := Unfold2(0, func(i int) maybe.Maybe[tuple.Triple[int, int, int]] {
+				if i >= int(n) {
+					return maybe.Nothing[tuple.Triple[int, int, int]]()
+				}
+				return maybe.Just(tuple.Triple[int, int, int]{First: i, Second: i * i, Third: i + 1})
+			})
+
+			cnt := 0
+			for i, square := range squares {
+				if i != cnt || square != i*i {
+					return false
+				}
+				cnt++
+			}
+			return cnt == int(n)
+		},
+		"Take(Iterate2(0, x, (i + 1, x)), n) == Enumerate(Repeat(x)) up to n": func(x int, n uint8) bool {
+			return slices.Equal(
+				slices.Collect(Take(SeqFromSeq2(Iterate2(0, x, func(i int, x int) (int, int) { return i + 1, x })), int(n))),
+				slices.Collect(Take(SeqFromSeq2(Enumerate(Repeat(x))), int(n))),
+			)
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package iter
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+// NOTE: Counterparts for `iter.Seq2` are bridged to the ones for `iter.Seq` via `tuple.KeyValuePair`.
+
+func Filter2[K any, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) iter.Seq2[K, V] {
+	return Seq2FromSeq(Filter(SeqFromSeq2(seq2), uncurry(predicate)))
+}
+
+func FlatMap2[K1 any, V1 any, K2 any, V2 any](seq2 iter.Seq2[K1, V1], f func(K1, V1) iter.Seq2[K2, V2]) iter.Seq2[K2, V2] {
+	return Seq2FromSeq(FlatMap(SeqFromSeq2(seq2), func(kvPair tuple.KeyValuePair[K1, V1]) iter.Seq[tuple.KeyValuePair[K2, V2]] {
+		return SeqFromSeq2(f(kvPair.Key, kvPair.Value))
+	}))
+}
+
+func TakeWhile2[K any, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) iter.Seq2[K, V] {
+	return Seq2FromSeq(TakeWhile(SeqFromSeq2(seq2), uncurry(predicate)))
+}
+
+func DropWhile2[K any, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) iter.Seq2[K, V] {
+	return Seq2FromSeq(DropWhile(SeqFromSeq2(seq2), uncurry(predicate)))
+}
+
+func Scan2[K any, V any, T any](seq2 iter.Seq2[K, V], init T, f func(acc T, k K, v V) T) iter.Seq[T] {
+	return Scan(SeqFromSeq2(seq2), init, func(acc T, kvPair tuple.KeyValuePair[K, V]) T {
+		return f(acc, kvPair.Key, kvPair.Value)
+	})
+}
+
+// CAUTION: Only invoke `Fold2` with finite `seq2`.
+func Fold2[K any, V any, T any](seq2 iter.Seq2[K, V], init T, f func(acc T, k K, v V) T) T {
+	return Fold(SeqFromSeq2(seq2), init, func(acc T, kvPair tuple.KeyValuePair[K, V]) T {
+		return f(acc, kvPair.Key, kvPair.Value)
+	})
+}
+
+func Find2[K any, V any](seq2 iter.Seq2[K, V], predicate func(K, V) bool) maybe.Maybe[tuple.KeyValuePair[K, V]] {
+	return Find(SeqFromSeq2(seq2), uncurry(predicate))
+}
+
+func Interleave2[K any, V any](seq2s ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	seqs := make([]iter.Seq[tuple.KeyValuePair[K, V]], 0, len(seq2s))
+	for _, seq2 := range seq2s {
+		seqs = append(seqs, SeqFromSeq2(seq2))
+	}
+	return Seq2FromSeq(Interleave(seqs...))
+}
+
+// CAUTION: Only invoke `Reduce2` with finite `seq2`.
+func Reduce2[K any, V any](seq2 iter.Seq2[K, V], f func(accK K, accV V, k K, v V) (K, V)) maybe.Maybe[tuple.KeyValuePair[K, V]] {
+	return Reduce(SeqFromSeq2(seq2), func(acc tuple.KeyValuePair[K, V], kvPair tuple.KeyValuePair[K, V]) tuple.KeyValuePair[K, V] {
+		k, v := f(acc.Key, acc.Value, kvPair.Key, kvPair.Value)
+		return tuple.KeyValuePair[K, V]{
+			Key:   k,
+			Value: v,
+		}
+	})
+}
+
+// CAUTION: `n` must be positive.
+func Chunk2[K any, V any](seq2 iter.Seq2[K, V], n int) iter.Seq[[]tuple.KeyValuePair[K, V]] {
+	return Chunk(SeqFromSeq2(seq2), n)
+}
+
+// CAUTION: `n` must be positive.
+func Window2[K any, V any](seq2 iter.Seq2[K, V], n int) iter.Seq[[]tuple.KeyValuePair[K, V]] {
+	return Window(SeqFromSeq2(seq2), n)
+}
+
+func Dedup2[K comparable, V comparable](seq2 iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return Seq2FromSeq(Dedup(SeqFromSeq2(seq2)))
+}
+
+func DedupFunc2[K any, V any](seq2 iter.Seq2[K, V], eq func(k1 K, v1 V, k2 K, v2 V) bool) iter.Seq2[K, V] {
+	return Seq2FromSeq(DedupFunc(SeqFromSeq2(seq2), func(l tuple.KeyValuePair[K, V], r tuple.KeyValuePair[K, V]) bool {
+		return eq(l.Key, l.Value, r.Key, r.Value)
+	}))
+}
+
+func Unfold2[K any, V any, S any](seed S, f func(S) maybe.Maybe[tuple.Triple[K, V, S]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for step := f(seed); step.IsJust(); step = f(step.Value().Third) {
+			if !yield(step.Value().First, step.Value().Second) {
+				return
+			}
+		}
+	}
+}
+
+// `Iterate2(k, v, f)` yields `(k, v), f(k, v), f(f(k, v)), ...` infinitely.
+func Iterate2[K any, V any](k K, v V, f func(K, V) (K, V)) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for ; yield(k, v); k, v = f(k, v) {
+		}
+	}
+}
+
+func uncurry[K any, V any, T any](f func(K, V) T) func(tuple.KeyValuePair[K, V]) T {
+	return func(kvPair tuple.KeyValuePair[K, V]) T {
+		return f(kvPair.Key, kvPair.Value)
+	}
+}
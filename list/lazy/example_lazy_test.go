@@ -0,0 +1,21 @@
+package lazy_test
+
+import (
+	"fmt"
+
+	"github.com/freebirdljj/immutable/list/lazy"
+)
+
+func sieve(xs *lazy.List[int]) *lazy.List[int] {
+	p, rest := xs.Uncons()
+	return lazy.Cons(p, func() *lazy.List[int] {
+		return sieve(lazy.Filter(rest, func(x int) bool { return x%p != 0 }))
+	})
+}
+
+func ExampleList() {
+	primes := sieve(lazy.Iterate(2, func(x int) int { return x + 1 }))
+	fmt.Println(lazy.Take(primes, 10).ToGoSlice())
+	// Output:
+	// [2 3 5 7 11 13 17 19 23 29]
+}
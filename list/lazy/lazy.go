@@ -0,0 +1,180 @@
+package lazy
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/freebirdljj/immutable/list"
+	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+type (
+	// `List[T]` is a list whose tail is computed on demand and memoised,
+	// which makes infinite lists beyond cycles possible. nil is the empty list.
+	List[T any] struct {
+		value T
+		next  func() *List[T]
+	}
+)
+
+// `Cons(x, next)` returns a list headed by `x`, whose tail is computed by `next` at most once.
+// CAUTION: `next` can't be nil.
+func Cons[T any](x T, next func() *List[T]) *List[T] {
+	return &List[T]{
+		value: x,
+		next:  sync.OnceValue(next),
+	}
+}
+
+func FromGoSlice[T any](xs []T) *List[T] {
+	if len(xs) == 0 {
+		return nil
+	}
+	return Cons(xs[0], func() *List[T] { return FromGoSlice(xs[1:]) })
+}
+
+// `FromList(xs)` converts `xs` lazily, so cyclic lists become infinite lazy lists.
+func FromList[T any](xs *list.List[T]) *List[T] {
+	if xs.Empty() {
+		return nil
+	}
+	x, next := xs.Uncons()
+	return Cons(x, func() *List[T] { return FromList(next) })
+}
+
+// `FromSeq(seq)` pulls values from `seq` only when they are demanded.
+// `stop` releases `seq`, after which the list ends at the last value pulled so far,
+// which happens by itself once the list is forced to its end.
+// CAUTION: Always invoke `stop` once the list is no longer needed, unless it has been forced to its end, just like `iter.Pull`.
+func FromSeq[T any](seq iter.Seq[T]) (xs *List[T], stop func()) {
+	next, stop := iter.Pull(seq)
+	return fromPull(next), stop
+}
+
+// `Iterate(x, f)` returns the infinite list `x, f(x), f(f(x)), ...`.
+func Iterate[T any](x T, f func(T) T) *List[T] {
+	return Cons(x, func() *List[T] { return Iterate(f(x), f) })
+}
+
+// `Unfold(seed, f)` builds a list from `seed`, which ends once `f` returns nothing.
+func Unfold[T any, S any](seed S, f func(S) maybe.Maybe[tuple.Pair[T, S]]) *List[T] {
+	step := f(seed)
+	if step.IsNothing() {
+		return nil
+	}
+	return Cons(step.Value().First, func() *List[T] { return Unfold(step.Value().Second, f) })
+}
+
+// `Generate(f)` returns the infinite list of values returned by successive calls to `f`.
+func Generate[T any](f func() T) *List[T] {
+	return Cons(f(), func() *List[T] { return Generate(f) })
+}
+
+func Map[T1 any, T2 any](xs *List[T1], f func(T1) T2) *List[T2] {
+	if xs == nil {
+		return nil
+	}
+	return Cons(f(xs.value), func() *List[T2] { return Map(xs.next(), f) })
+}
+
+func Zip[T1 any, T2 any](xs *List[T1], ys *List[T2]) *List[tuple.Pair[T1, T2]] {
+	if xs == nil || ys == nil {
+		return nil
+	}
+	return Cons(
+		tuple.Pair[T1, T2]{First: xs.value, Second: ys.value},
+		func() *List[tuple.Pair[T1, T2]] { return Zip(xs.next(), ys.next()) },
+	)
+}
+
+// `Filter(xs, predicate)` forces `xs` up to the first satisfying value only.
+// CAUTION: Never invoke `Filter` with infinite list without satisfying values.
+func Filter[T any](xs *List[T], predicate func(T) bool) *List[T] {
+	for p := xs; p != nil; p = p.next() {
+		if predicate(p.value) {
+			return Cons(p.value, func() *List[T] { return Filter(p.next(), predicate) })
+		}
+	}
+	return nil
+}
+
+func Take[T any](xs *List[T], n int) *List[T] {
+	if xs == nil || n <= 0 {
+		return nil
+	}
+	return Cons(xs.value, func() *List[T] {
+		// NOTE: Avoid forcing values beyond the first `n`.
+		if n == 1 {
+			return nil
+		}
+		return Take(xs.next(), n-1)
+	})
+}
+
+func Drop[T any](xs *List[T], n int) *List[T] {
+	p := xs
+	for p != nil && n > 0 {
+		n--
+		p = p.next()
+	}
+	return p
+}
+
+func fromPull[T any](next func() (T, bool)) *List[T] {
+	x, ok := next()
+	if !ok {
+		return nil
+	}
+	return Cons(x, func() *List[T] { return fromPull(next) })
+}
+
+// CAUTION: `xs` can't be nil.
+func (xs *List[T]) Uncons() (value T, next *List[T]) {
+	return xs.value, xs.next()
+}
+
+func (xs *List[T]) Empty() bool {
+	return xs == nil
+}
+
+// `AppendFunc(ys)` returns `xs` followed by `ys()`, where `ys` is called only once the end of `xs` is reached.
+func (xs *List[T]) AppendFunc(ys func() *List[T]) *List[T] {
+	if xs == nil {
+		return ys()
+	}
+	return Cons(xs.value, func() *List[T] { return xs.next().AppendFunc(ys) })
+}
+
+// CAUTION: Only invoke `Reverse()` with finite list.
+func (xs *List[T]) Reverse() *List[T] {
+	res := (*List[T])(nil)
+	for p := xs; p != nil; p = p.next() {
+		// NOTE: Tails of the result are already evaluated, so there is nothing to memoise.
+		rest := res
+		res = &List[T]{
+			value: p.value,
+			next:  func() *List[T] { return rest },
+		}
+	}
+	return res
+}
+
+func (xs *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for p := xs; p != nil; p = p.next() {
+			if !yield(p.value) {
+				return
+			}
+		}
+	}
+}
+
+// CAUTION: Only invoke `ToGoSlice()` with finite list.
+func (xs *List[T]) ToGoSlice() []T {
+	res := []T(nil)
+	for p := xs; p != nil; p = p.next() {
+		res = append(res, p.value)
+	}
+	return res
+}
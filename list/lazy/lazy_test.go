@@ -0,0 +1,202 @@
+package lazy
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/list"
+	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+func TestIterate(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"take(iterate(x, +1), n) == [x, x + n)": func(x int16, n uint8) bool {
+
+			expected := []int(nil)
+			for i := range int(n) {
+				expected = append(expected, int(x)+i)
+			}
+
+			return slices.Equal(Take(Iterate(int(x), func(x int) int { return x + 1 }), int(n)).ToGoSlice(), expected)
+		},
+	})
+}
+
+func TestUnfold(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"unfold(xs, uncons) == xs": func(xs []int) bool {
+
+			uncons := func(xs []int) maybe.Maybe[tuple.Pair[int, []int]] {
+				if len(xs) == 0 {
+					return maybe.Nothing[tuple.Pair[int, []int]]()
+				}
+				return maybe.Just(tuple.Pair[int, []int]{First: xs[0], Second: xs[1:]})
+			}
+
+			return slices.Equal(Unfold(xs, uncons).ToGoSlice(), xs)
+		},
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"values of generate(f) should be computed once and on demand": func(n uint8) bool {
+
+			calls := 0
+			xs := Generate(func() int { calls++; return calls })
+
+			first := Take(xs, int(n)).ToGoSlice()
+			callsAfterFirstPass := calls
+			second := Take(xs, int(n)).ToGoSlice()
+
+			return slices.Equal(first, second) && calls == callsAfterFirstPass && calls == max(int(n), 1)
+		},
+	})
+}
+
+func TestFromSeq(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromSeq(values(xs)) == xs": func(xs []int) bool {
+			xl, _ := FromSeq(slices.Values(xs))
+			return slices.Equal(xl.ToGoSlice(), xs)
+		},
+		"fromSeq(seq) should pull no more values than demanded": func(xs []int, n uint8) bool {
+
+			pulled := 0
+			seq := func(yield func(int) bool) {
+				for _, x := range xs {
+					pulled++
+					if !yield(x) {
+						return
+					}
+				}
+			}
+
+			xl, stop := FromSeq(seq)
+			defer stop()
+
+			taken := Take(xl, int(n)).ToGoSlice()
+			return slices.Equal(taken, xs[:min(len(xs), int(n))]) && pulled == min(len(xs), max(int(n), 1))
+		},
+		"stop() should release seq and end the list at the last value pulled": func(n uint8) bool {
+
+			released := false
+			seq := func(yield func(int) bool) {
+				defer func() { released = true }()
+				for i := 0; yield(i); i++ {
+				}
+			}
+
+			xl, stop := FromSeq(seq)
+			taken := Take(xl, int(n)).ToGoSlice()
+			stop()
+
+			return released && len(taken) == int(n) && len(xl.ToGoSlice()) == max(int(n), 1)
+		},
+	})
+}
+
+func TestFromList(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"take(fromList(cycle(xs)), n) == cycle(xs).take(n)": func(xs []int, last int, n uint8) bool {
+			xl := list.Cycle(list.FromGoSlice(append(xs, last)))
+			return slices.Equal(Take(FromList(xl), int(n)).ToGoSlice(), xl.Take(int(n)).ToGoSlice())
+		},
+	})
+}
+
+func TestMap(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"map(fromGoSlice(xs), f) == fromGoSlice(map(xs, f))": func(xs []int) bool {
+
+			f := func(x int) int { return x * 2 }
+
+			expected := []int(nil)
+			for _, x := range xs {
+				expected = append(expected, f(x))
+			}
+
+			return slices.Equal(Map(FromGoSlice(xs), f).ToGoSlice(), expected)
+		},
+		"map(iterate(x, f), g) should work on demand": func(x int, n uint8) bool {
+			succ := func(x int) int { return x + 1 }
+			return slices.Equal(
+				Take(Map(Iterate(x, succ), succ), int(n)).ToGoSlice(),
+				Take(Iterate(x+1, succ), int(n)).ToGoSlice(),
+			)
+		},
+	})
+}
+
+func TestListFilter(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"take(filter(iterate(0, +1), even), n) == take(iterate(0, +2), n)": func(n uint8) bool {
+			return slices.Equal(
+				Take(Filter(Iterate(0, func(x int) int { return x + 1 }), func(x int) bool { return x%2 == 0 }), int(n)).ToGoSlice(),
+				Take(Iterate(0, func(x int) int { return x + 2 }), int(n)).ToGoSlice(),
+			)
+		},
+		"filter(fromGoSlice(xs), p) == filter(xs, p)": func(xs []int) bool {
+
+			even := func(x int) bool { return x%2 == 0 }
+
+			expected := []int(nil)
+			for _, x := range xs {
+				if even(x) {
+					expected = append(expected, x)
+				}
+			}
+
+			return slices.Equal(Filter(FromGoSlice(xs), even).ToGoSlice(), expected)
+		},
+	})
+}
+
+func TestListTakeAndDrop(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"take(xs, n) ++ drop(xs, n) == xs": func(xs []int, n uint8) bool {
+			l := FromGoSlice(xs)
+			return slices.Equal(append(Take(l, int(n)).ToGoSlice(), Drop(l, int(n)).ToGoSlice()...), xs)
+		},
+	})
+}
+
+func TestZip(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"zip(xs, iterate(0, +1)) enumerates xs": func(xs []int) bool {
+
+			i := 0
+			for pair := range Zip(FromGoSlice(xs), Iterate(0, func(x int) int { return x + 1 })).All() {
+				if pair.First != xs[i] || pair.Second != i {
+					return false
+				}
+				i++
+			}
+			return i == len(xs)
+		},
+	})
+}
+
+func TestListAppendFuncAndReverse(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"xs.appendFunc(ys) == xs ++ ys and ys is called at most once": func(xs []int, ys []int) bool {
+
+			calls := 0
+			appended := FromGoSlice(xs).AppendFunc(func() *List[int] { calls++; return FromGoSlice(ys) })
+
+			return slices.Equal(appended.ToGoSlice(), append(slices.Clone(xs), ys...)) &&
+				slices.Equal(appended.ToGoSlice(), append(slices.Clone(xs), ys...)) && calls == 1
+		},
+		"take(iterate(0, +1).appendFunc(f), n) never calls f": func(n uint8) bool {
+			appended := Iterate(0, func(x int) int { return x + 1 }).AppendFunc(func() *List[int] { panic("unreachable") })
+			return len(Take(appended, int(n)).ToGoSlice()) == int(n)
+		},
+		"fromGoSlice(xs).reverse() == reverse(xs)": func(xs []int) bool {
+			reversed := slices.Clone(xs)
+			slices.Reverse(reversed)
+			return slices.Equal(FromGoSlice(xs).Reverse().ToGoSlice(), reversed)
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/freebirdljj/immutable/comparator"
 	immutable_func "github.com/freebirdljj/immutable/func"
 	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
 )
 
 type (
@@ -100,6 +101,98 @@ func Concat[T any](xss *List[*List[T]]) *List[T] {
 	})
 }
 
+// `Zip(xs, ys)` terminates with the shorter one if any is finite,
+// otherwise cycles with the least common multiple of both periods.
+func Zip[T1 any, T2 any](xs *List[T1], ys *List[T2]) *List[tuple.Pair[T1, T2]] {
+	return ZipWith(xs, ys, func(x T1, y T2) tuple.Pair[T1, T2] {
+		return tuple.Pair[T1, T2]{First: x, Second: y}
+	})
+}
+
+func ZipWith[T1 any, T2 any, T3 any](xs *List[T1], ys *List[T2], f func(T1, T2) T3) *List[T3] {
+	return zipList(
+		tuple.Pair[*List[T1], *List[T2]]{First: xs, Second: ys},
+		func(p tuple.Pair[*List[T1], *List[T2]]) maybe.Maybe[T3] {
+			if p.First == nil || p.Second == nil {
+				return maybe.Nothing[T3]()
+			}
+			return maybe.Just(f(p.First.value, p.Second.value))
+		},
+		func(p tuple.Pair[*List[T1], *List[T2]]) tuple.Pair[*List[T1], *List[T2]] {
+			return tuple.Pair[*List[T1], *List[T2]]{First: p.First.next, Second: p.Second.next}
+		},
+	)
+}
+
+func Zip3[T1 any, T2 any, T3 any](xs *List[T1], ys *List[T2], zs *List[T3]) *List[tuple.Triple[T1, T2, T3]] {
+	return zipList(
+		tuple.Triple[*List[T1], *List[T2], *List[T3]]{First: xs, Second: ys, Third: zs},
+		func(p tuple.Triple[*List[T1], *List[T2], *List[T3]]) maybe.Maybe[tuple.Triple[T1, T2, T3]] {
+			if p.First == nil || p.Second == nil || p.Third == nil {
+				return maybe.Nothing[tuple.Triple[T1, T2, T3]]()
+			}
+			return maybe.Just(tuple.Triple[T1, T2, T3]{First: p.First.value, Second: p.Second.value, Third: p.Third.value})
+		},
+		func(p tuple.Triple[*List[T1], *List[T2], *List[T3]]) tuple.Triple[*List[T1], *List[T2], *List[T3]] {
+			return tuple.Triple[*List[T1], *List[T2], *List[T3]]{First: p.First.next, Second: p.Second.next, Third: p.Third.next}
+		},
+	)
+}
+
+// `ZipLongest(xs, ys)` is like `Zip(xs, ys)`, but pads the shorter one with nothing.
+func ZipLongest[T1 any, T2 any](xs *List[T1], ys *List[T2]) *List[tuple.Pair[maybe.Maybe[T1], maybe.Maybe[T2]]] {
+	return zipList(
+		tuple.Pair[*List[T1], *List[T2]]{First: xs, Second: ys},
+		func(p tuple.Pair[*List[T1], *List[T2]]) maybe.Maybe[tuple.Pair[maybe.Maybe[T1], maybe.Maybe[T2]]] {
+			if p.First == nil && p.Second == nil {
+				return maybe.Nothing[tuple.Pair[maybe.Maybe[T1], maybe.Maybe[T2]]]()
+			}
+			return maybe.Just(tuple.Pair[maybe.Maybe[T1], maybe.Maybe[T2]]{
+				First:  p.First.head(),
+				Second: p.Second.head(),
+			})
+		},
+		func(p tuple.Pair[*List[T1], *List[T2]]) tuple.Pair[*List[T1], *List[T2]] {
+			return tuple.Pair[*List[T1], *List[T2]]{First: p.First.tail(), Second: p.Second.tail()}
+		},
+	)
+}
+
+func Unzip[T1 any, T2 any](xys *List[tuple.Pair[T1, T2]]) (xs *List[T1], ys *List[T2]) {
+	return Map(xys, func(xy tuple.Pair[T1, T2]) T1 { return xy.First }),
+		Map(xys, func(xy tuple.Pair[T1, T2]) T2 { return xy.Second })
+}
+
+// `zipList()` walks the lists in `cursor` in lockstep.
+// Once `cursor` comes back to a visited state, the result is tied into a cycle there.
+func zipList[Cursor comparable, T any](cursor Cursor, value func(Cursor) maybe.Maybe[T], next func(Cursor) Cursor) *List[T] {
+
+	head := List[T]{}
+	prev := &head
+	visited := make(map[Cursor]*List[T])
+
+	for {
+
+		if node, has := visited[cursor]; has {
+			prev.next = node
+			break
+		}
+
+		v := value(cursor)
+		if v.IsNothing() {
+			break
+		}
+
+		prev.next = Cons(v.Value(), nil)
+		prev = prev.next
+		visited[cursor] = prev
+
+		cursor = next(cursor)
+	}
+
+	return head.next
+}
+
 // NOTE: The `next` field of the last node of the list returned by `f` may be modified
 func maplist[T1 any, T2 any](xs *List[T1], f func(*List[T1]) *List[T2]) *List[T2] {
 
@@ -326,6 +419,20 @@ func (xs *List[T]) ToGoSlice() []T {
 	return res
 }
 
+func (xs *List[T]) head() maybe.Maybe[T] {
+	if xs == nil {
+		return maybe.Nothing[T]()
+	}
+	return maybe.Just(xs.value)
+}
+
+func (xs *List[T]) tail() *List[T] {
+	if xs == nil {
+		return nil
+	}
+	return xs.next
+}
+
 func (xs *List[T]) clone() *List[T] {
 	return maplist(xs, func(p *List[T]) *List[T] {
 		if p == nil {
@@ -11,6 +11,8 @@ import (
 	"github.com/freebirdljj/immutable/comparator"
 	immutable_func "github.com/freebirdljj/immutable/func"
 	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
 )
 
 func TestCycle(t *testing.T) {
@@ -389,6 +391,122 @@ func TestListAny(t *testing.T) {
 	})
 }
 
+func TestZip(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"zip(xs, ys) == [(xs[i], ys[i]) for i < min(len(xs), len(ys))]": func(xs []int, ys []string) bool {
+
+			expected := []tuple.Pair[int, string](nil)
+			for i := range min(len(xs), len(ys)) {
+				expected = append(expected, tuple.Pair[int, string]{First: xs[i], Second: ys[i]})
+			}
+
+			return slicesEqual(Zip(FromGoSlice(xs), FromGoSlice(ys)).ToGoSlice(), expected)
+		},
+		"zip(repeat(x), xs) terminates with xs": func(x int, xs []int) bool {
+			return Zip(Repeat(x), FromGoSlice(xs)).Length() == len(xs) &&
+				Zip(FromGoSlice(xs), Repeat(x)).Length() == len(xs)
+		},
+		"zip(cycle(xs), cycle(ys)) cycles with lcm(len(xs), len(ys))": func(xs []int, xLast int, ys []int, yLast int) bool {
+
+			nonemptyX, nonemptyY := append(xs, xLast), append(ys, yLast)
+			period := lcm(len(nonemptyX), len(nonemptyY))
+
+			expected := []tuple.Pair[int, int](nil)
+			for i := range 2 * period {
+				expected = append(expected, tuple.Pair[int, int]{First: nonemptyX[i%len(nonemptyX)], Second: nonemptyY[i%len(nonemptyY)]})
+			}
+
+			zipped := Zip(Cycle(FromGoSlice(nonemptyX)), Cycle(FromGoSlice(nonemptyY)))
+			return !zipped.isFinite() && nodeCount(zipped) == period && slicesEqual(zipped.Take(2*period).ToGoSlice(), expected)
+		},
+		"zip(x : cycle(xs), cycle(ys)) == zip(x : xs ++ cycle(xs), cycle(ys))": func(x int, xs []int, xLast int, ys []int, yLast int) bool {
+
+			nonemptyX, nonemptyY := append(xs, xLast), append(ys, yLast)
+			sampleLen := 1 + 2*lcm(len(nonemptyX), len(nonemptyY))
+
+			return slicesEqual(
+				Zip(Cons(x, Cycle(FromGoSlice(nonemptyX))), Cycle(FromGoSlice(nonemptyY))).Take(sampleLen).ToGoSlice(),
+				Zip(Cons(x, FromGoSlice(nonemptyX).Append(Cycle(FromGoSlice(nonemptyX)))), Cycle(FromGoSlice(nonemptyY))).Take(sampleLen).ToGoSlice(),
+			)
+		},
+	})
+}
+
+func TestZipWith(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"zipWith(xs, ys, f) == zip(xs, ys).map(f)": func(xs []int, ys []int) bool {
+			xl, yl := FromGoSlice(xs), FromGoSlice(ys)
+			return slicesEqual(
+				ZipWith(xl, yl, func(x int, y int) int { return x - y }).ToGoSlice(),
+				Map(Zip(xl, yl), func(xy tuple.Pair[int, int]) int { return xy.First - xy.Second }).ToGoSlice(),
+			)
+		},
+	})
+}
+
+func TestZip3(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"zip3(cycle(xs), repeat(y), zs) terminates with zs": func(xs []int, xLast int, y int, zs []int) bool {
+
+			expected := []tuple.Triple[int, int, int](nil)
+			nonemptyX := append(xs, xLast)
+			for i, z := range zs {
+				expected = append(expected, tuple.Triple[int, int, int]{First: nonemptyX[i%len(nonemptyX)], Second: y, Third: z})
+			}
+
+			return slicesEqual(Zip3(Cycle(FromGoSlice(nonemptyX)), Repeat(y), FromGoSlice(zs)).ToGoSlice(), expected)
+		},
+	})
+}
+
+func TestZipLongest(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"zipLongest(xs, ys).length() == max(len(xs), len(ys))": func(xs []int, ys []int) bool {
+			return ZipLongest(FromGoSlice(xs), FromGoSlice(ys)).Length() == max(len(xs), len(ys))
+		},
+		"zipLongest(cycle(xs), ys) pads ys with nothing forever": func(xs []int, xLast int, ys []int) bool {
+
+			nonemptyX := append(xs, xLast)
+			sampleLen := len(ys) + 2*len(nonemptyX)
+
+			expected := []tuple.Pair[maybe.Maybe[int], maybe.Maybe[int]](nil)
+			for i := range sampleLen {
+				pair := tuple.Pair[maybe.Maybe[int], maybe.Maybe[int]]{First: maybe.Just(nonemptyX[i%len(nonemptyX)])}
+				if i < len(ys) {
+					pair.Second = maybe.Just(ys[i])
+				}
+				expected = append(expected, pair)
+			}
+
+			zipped := ZipLongest(Cycle(FromGoSlice(nonemptyX)), FromGoSlice(ys))
+			return !zipped.isFinite() && slicesEqual(zipped.Take(sampleLen).ToGoSlice(), expected)
+		},
+	})
+}
+
+func TestUnzip(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"unzip(zip(xs, ys)) == (xs, ys) if len(xs) == len(ys)": func(xys map[int]string) bool {
+
+			xs, ys := []int(nil), []string(nil)
+			for x, y := range xys {
+				xs, ys = append(xs, x), append(ys, y)
+			}
+
+			unzippedX, unzippedY := Unzip(Zip(FromGoSlice(xs), FromGoSlice(ys)))
+			return slicesEqual(unzippedX.ToGoSlice(), xs) && slicesEqual(unzippedY.ToGoSlice(), ys)
+		},
+		"unzip(zip(cycle(xs), repeat(y))) == (cycle(xs), repeat(y))": func(xs []int, xLast int, y int) bool {
+
+			nonemptyX := append(xs, xLast)
+			unzippedX, unzippedY := Unzip(Zip(Cycle(FromGoSlice(nonemptyX)), Repeat(y)))
+
+			return unzippedX.IsIsomorphicTo(Cycle(FromGoSlice(nonemptyX)), comparator.OrderedComparator[int]) &&
+				unzippedY.IsIsomorphicTo(Repeat(y), comparator.OrderedComparator[int])
+		},
+	})
+}
+
 func slicesEqual[T any](v1 []T, v2 []T) bool {
 	return (len(v1) == 0 && len(v2) == 0) || reflect.DeepEqual(v1, v2)
 }
@@ -397,3 +515,21 @@ func listsElementsMatch[T cmp.Ordered](xs *List[T], ys *List[T]) bool {
 	comparator := comparator.OrderedComparator[T]
 	return xs.Sort(comparator).IsIsomorphicTo(ys.Sort(comparator), comparator)
 }
+
+func nodeCount[T any](xs *List[T]) int {
+	visited := make(map[*List[T]]bool)
+	for p := xs; p != nil && !visited[p]; p = p.next {
+		visited[p] = true
+	}
+	return len(visited)
+}
+
+func lcm(a int, b int) int {
+	gcd := func(a int, b int) int {
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	}
+	return a / gcd(a, b) * b
+}
@@ -0,0 +1,78 @@
+package immutable_map
+
+import (
+	"github.com/freebirdljj/immutable/comparator"
+	immutable_func "github.com/freebirdljj/immutable/func"
+	"github.com/freebirdljj/immutable/maybe"
+	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+type (
+	// `Builder` is a mutable builder of `Map` for batch modifications.
+	// CAUTION: `Builder` is not safe for concurrent use.
+	Builder[Key any, Value any] immutable_rb_tree.Transient[tuple.KeyValuePair[Key, Value]]
+)
+
+func NewBuilder[Key any, Value any](cmp comparator.Comparator[Key]) *Builder[Key, Value] {
+	return New[Key, Value](cmp).Transient()
+}
+
+// `Transient()` returns a mutable builder starting with all key-value pairs of `m`, `m` itself is never affected.
+func (m *Map[Key, Value]) Transient() *Builder[Key, Value] {
+	return (*Builder[Key, Value])(m.rbTree().Transient())
+}
+
+// `Persistent()` returns a `Map` of all key-value pairs so far,
+// which will never be affected by any subsequent modification to `b`.
+func (b *Builder[Key, Value]) Persistent() *Map[Key, Value] {
+	return (*Map[Key, Value])(b.transient().Persistent())
+}
+
+func (b *Builder[Key, Value]) Empty() bool {
+	return b.transient().Empty()
+}
+
+func (b *Builder[Key, Value]) Count() int {
+	return b.transient().Count()
+}
+
+func (b *Builder[Key, Value]) Index(key Key) (value Value, has bool) {
+	kv := b.transient().Lookup(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+	if kv.IsNothing() {
+		return immutable_func.Zero[Value](), false
+	}
+	return kv.Value().Value, true
+}
+
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+func (b *Builder[Key, Value]) Insert(key Key, value Value) (affected bool) {
+	return b.transient().Insert(tuple.KeyValuePair[Key, Value]{
+		Key:   key,
+		Value: value,
+	})
+}
+
+// `affected` is true, meaning that a real deletion occurred; otherwise nothing happens.
+func (b *Builder[Key, Value]) Delete(key Key) (affected bool) {
+	return b.transient().Delete(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+}
+
+// `Alter(key, f)` is the in-place counterpart of `Map.Alter(key, f)`.
+func (b *Builder[Key, Value]) Alter(key Key, f func(old maybe.Maybe[Value]) maybe.Maybe[Value]) (old maybe.Maybe[Value]) {
+	oldKVPair := b.transient().Alter(
+		tuple.KeyValuePair[Key, Value]{
+			Key: key,
+		},
+		kvPairAlteration(key, f),
+	)
+	return maybe.Map(oldKVPair, kvPairValue[Key, Value])
+}
+
+func (b *Builder[Key, Value]) transient() *immutable_rb_tree.Transient[tuple.KeyValuePair[Key, Value]] {
+	return (*immutable_rb_tree.Transient[tuple.KeyValuePair[Key, Value]])(b)
+}
@@ -0,0 +1,49 @@
+package immutable_map
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+func TestBuilder(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"building from a go map should be the same as fromGoMap(goMap)": func(xs map[int]string, ys []int) bool {
+
+			cmp := comparator.OrderedComparator[int]
+
+			b := NewBuilder[int, string](cmp)
+			for k, v := range xs {
+				b.Insert(k, v)
+			}
+
+			expected := FromGoMap(cmp, xs)
+			for _, y := range ys {
+				b.Delete(y)
+				expected, _ = expected.Delete(y)
+			}
+
+			return reflect.DeepEqual(ToGoMap(b.Persistent()), ToGoMap(expected)) && b.Count() == expected.Count()
+		},
+		"modifying a builder should never affect maps it has returned": func(xs map[int8]int, ys []int8) bool {
+
+			b := FromGoMap(comparator.OrderedComparator[int8], xs).Transient()
+			m := b.Persistent()
+
+			for _, y := range ys {
+				b.Alter(y, func(old maybe.Maybe[int]) maybe.Maybe[int] { return maybe.Just(old.OrValue(0) + 1) })
+			}
+
+			for _, y := range ys {
+				if _, has := b.Index(y); !has {
+					return false
+				}
+			}
+
+			return reflect.DeepEqual(ToGoMap(m), xs)
+		},
+	})
+}
@@ -2,10 +2,12 @@ package immutable_map
 
 import (
 	"iter"
+	"maps"
 
 	"github.com/freebirdljj/immutable/comparator"
 	immutable_func "github.com/freebirdljj/immutable/func"
 	immutable_iter "github.com/freebirdljj/immutable/iter"
+	"github.com/freebirdljj/immutable/maybe"
 	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
 	"github.com/freebirdljj/immutable/tuple"
 )
@@ -15,28 +17,36 @@ type (
 )
 
 func New[Key any, Value any](cmp comparator.Comparator[Key]) *Map[Key, Value] {
-	return (*Map[Key, Value])(immutable_rb_tree.New(
-		func(l tuple.KeyValuePair[Key, Value], r tuple.KeyValuePair[Key, Value]) int {
-			return cmp(l.Key, r.Key)
-		},
-	))
+	return (*Map[Key, Value])(immutable_rb_tree.New(kvPairComparator[Value](cmp)))
 }
 
 func FromGoMap[Key comparable, Value any](cmp comparator.Comparator[Key], goMap map[Key]Value) *Map[Key, Value] {
-	m := New[Key, Value](cmp)
-	for k, v := range goMap {
-		m, _ = m.Insert(k, v)
-	}
-	return m
+	return FromSeq(cmp, maps.All(goMap))
 }
 
+// Among key-value pairs with equal keys, the last one is preserved.
 func FromKeyValuePairs[Key any, Value any](cmp comparator.Comparator[Key], kvPairs ...tuple.KeyValuePair[Key, Value]) *Map[Key, Value] {
-	return (*Map[Key, Value])(immutable_rb_tree.FromValues(
-		func(l tuple.KeyValuePair[Key, Value], r tuple.KeyValuePair[Key, Value]) int {
-			return cmp(l.Key, r.Key)
-		},
-		kvPairs...,
-	))
+	return (*Map[Key, Value])(immutable_rb_tree.FromValues(kvPairComparator[Value](cmp), kvPairs...))
+}
+
+// `FromSeq()` sorts all key-value pairs of `seq` and then builds the map in linear time.
+// Among key-value pairs with equal keys, the last one is preserved.
+func FromSeq[Key any, Value any](cmp comparator.Comparator[Key], seq iter.Seq2[Key, Value]) *Map[Key, Value] {
+	return (*Map[Key, Value])(immutable_rb_tree.FromSeq(kvPairComparator[Value](cmp), immutable_iter.SeqFromSeq2(seq)))
+}
+
+// `FromSortedKeyValuePairs()` builds the map in linear time.
+// Among key-value pairs with equal keys, the last one is preserved.
+// CAUTION: Only invoke `FromSortedKeyValuePairs` with `kvPairs` sorted in ascending order of keys.
+func FromSortedKeyValuePairs[Key any, Value any](cmp comparator.Comparator[Key], kvPairs ...tuple.KeyValuePair[Key, Value]) *Map[Key, Value] {
+	return (*Map[Key, Value])(immutable_rb_tree.FromSortedValues(kvPairComparator[Value](cmp), kvPairs...))
+}
+
+// `FromSortedSeq()` builds the map in linear time.
+// Among key-value pairs with equal keys, the last one is preserved.
+// CAUTION: Only invoke `FromSortedSeq` with finite `seq` sorted in ascending order of keys.
+func FromSortedSeq[Key any, Value any](cmp comparator.Comparator[Key], seq iter.Seq2[Key, Value]) *Map[Key, Value] {
+	return (*Map[Key, Value])(immutable_rb_tree.FromSortedSeq(kvPairComparator[Value](cmp), immutable_iter.SeqFromSeq2(seq)))
 }
 
 func ToGoMap[Key comparable, Value any](m *Map[Key, Value]) map[Key]Value {
@@ -47,6 +57,35 @@ func ToGoMap[Key comparable, Value any](m *Map[Key, Value]) map[Key]Value {
 	return goMap
 }
 
+// `Diff(oldMap, newMap)` returns an iterator of keys whose values are added, removed or changed from `oldMap` to `newMap` in ascending order,
+// skipping subtrees shared by both, which makes diffing versions derived from each other cheap.
+// CAUTION: Only invoke `Diff` with maps sharing the same comparator.
+func Diff[Key any, Value comparable](oldMap *Map[Key, Value], newMap *Map[Key, Value]) iter.Seq2[Key, immutable_rb_tree.Change[Value]] {
+	return DiffFunc(oldMap, newMap, func(l Value, r Value) bool { return l == r })
+}
+
+// `DiffFunc(oldMap, newMap, eq)` is like `Diff(oldMap, newMap)`, but values are compared by `eq`.
+func DiffFunc[Key any, Value any](oldMap *Map[Key, Value], newMap *Map[Key, Value], eq func(old Value, new Value) bool) iter.Seq2[Key, immutable_rb_tree.Change[Value]] {
+	return func(yield func(Key, immutable_rb_tree.Change[Value]) bool) {
+		changes := immutable_rb_tree.Diff(
+			oldMap.rbTree(),
+			newMap.rbTree(),
+			func(l tuple.KeyValuePair[Key, Value], r tuple.KeyValuePair[Key, Value]) bool {
+				return eq(l.Value, r.Value)
+			},
+		)
+		for change := range changes {
+			key := change.New.OrValue(change.Old.OrValue(tuple.KeyValuePair[Key, Value]{})).Key
+			if !yield(key, immutable_rb_tree.Change[Value]{
+				Old: maybe.Map(change.Old, kvPairValue[Key, Value]),
+				New: maybe.Map(change.New, kvPairValue[Key, Value]),
+			}) {
+				return
+			}
+		}
+	}
+}
+
 func (m *Map[Key, Value]) Empty() bool {
 	return m.rbTree().Empty()
 }
@@ -65,10 +104,119 @@ func (m *Map[Key, Value]) Index(key Key) (value Value, has bool) {
 	return kv.Value, true
 }
 
+// `Max()` returns the key-value pair with the greatest key.
+func (m *Map[Key, Value]) Max() maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+	return m.rbTree().Max()
+}
+
+// `Min()` returns the key-value pair with the least key.
+func (m *Map[Key, Value]) Min() maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+	return m.rbTree().Min()
+}
+
+// `PopMax()` removes the key-value pair with the greatest key, `newMap` is the original one if the map is empty.
+func (m *Map[Key, Value]) PopMax() (newMap *Map[Key, Value], max maybe.Maybe[tuple.KeyValuePair[Key, Value]]) {
+	newRBTree, max := m.rbTree().PopMax()
+	return (*Map[Key, Value])(newRBTree), max
+}
+
+// `PopMin()` removes the key-value pair with the least key, `newMap` is the original one if the map is empty.
+func (m *Map[Key, Value]) PopMin() (newMap *Map[Key, Value], min maybe.Maybe[tuple.KeyValuePair[Key, Value]]) {
+	newRBTree, min := m.rbTree().PopMin()
+	return (*Map[Key, Value])(newRBTree), min
+}
+
+// `Floor(key)` returns the key-value pair with the greatest key less than or equal to `key`.
+func (m *Map[Key, Value]) Floor(key Key) maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+	return m.rbTree().Floor(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+}
+
+// `Ceiling(key)` returns the key-value pair with the least key greater than or equal to `key`.
+func (m *Map[Key, Value]) Ceiling(key Key) maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+	return m.rbTree().Ceiling(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+}
+
+// `Lower(key)` returns the key-value pair with the greatest key strictly less than `key`.
+func (m *Map[Key, Value]) Lower(key Key) maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+	return m.rbTree().Lower(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+}
+
+// `Higher(key)` returns the key-value pair with the least key strictly greater than `key`.
+func (m *Map[Key, Value]) Higher(key Key) maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+	return m.rbTree().Higher(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+}
+
+// `At(k)` returns the key-value pair with the k-th (0-indexed) smallest key, `has` is false if `k` is out of range.
+func (m *Map[Key, Value]) At(k int) (key Key, value Value, has bool) {
+	kv := m.rbTree().At(k)
+	if kv == nil {
+		return immutable_func.Zero[Key](), immutable_func.Zero[Value](), false
+	}
+	return kv.Key, kv.Value, true
+}
+
+// `Rank(key)` returns the number of keys which are less than `key`.
+func (m *Map[Key, Value]) Rank(key Key) int {
+	return m.rbTree().Rank(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+}
+
+// `CountRange(lo, hi)` returns the number of keys in the half-open range [lo, hi).
+func (m *Map[Key, Value]) CountRange(lo Key, hi Key) int {
+	return m.rbTree().CountRange(
+		tuple.KeyValuePair[Key, Value]{Key: lo},
+		tuple.KeyValuePair[Key, Value]{Key: hi},
+	)
+}
+
 func (m *Map[Key, Value]) All() iter.Seq2[Key, Value] {
 	return immutable_iter.Seq2FromSeq(m.rbTree().All())
 }
 
+// `Range(lo, hi)` returns an iterator of all key-value pairs whose keys are between `lo` and `hi` in ascending order.
+func (m *Map[Key, Value]) Range(lo immutable_rb_tree.Bound[Key], hi immutable_rb_tree.Bound[Key]) iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.rbTree().Range(kvPairBound[Value](lo), kvPairBound[Value](hi)))
+}
+
+// `From(lo)` returns an iterator of all key-value pairs whose keys are above `lo` in ascending order.
+func (m *Map[Key, Value]) From(lo immutable_rb_tree.Bound[Key]) iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.rbTree().From(kvPairBound[Value](lo)))
+}
+
+// `UpTo(hi)` returns an iterator of all key-value pairs whose keys are below `hi` in ascending order.
+func (m *Map[Key, Value]) UpTo(hi immutable_rb_tree.Bound[Key]) iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.rbTree().UpTo(kvPairBound[Value](hi)))
+}
+
+// `Backward()` returns an iterator of all key-value pairs in descending order of keys.
+func (m *Map[Key, Value]) Backward() iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.rbTree().Backward())
+}
+
+// `BackwardRange(lo, hi)` returns an iterator of all key-value pairs whose keys are between `lo` and `hi` in descending order.
+func (m *Map[Key, Value]) BackwardRange(lo immutable_rb_tree.Bound[Key], hi immutable_rb_tree.Bound[Key]) iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.rbTree().BackwardRange(kvPairBound[Value](lo), kvPairBound[Value](hi)))
+}
+
+// `BackwardFrom(lo)` returns an iterator of all key-value pairs whose keys are above `lo` in descending order.
+func (m *Map[Key, Value]) BackwardFrom(lo immutable_rb_tree.Bound[Key]) iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.rbTree().BackwardFrom(kvPairBound[Value](lo)))
+}
+
+// `BackwardUpTo(hi)` returns an iterator of all key-value pairs whose keys are below `hi` in descending order.
+func (m *Map[Key, Value]) BackwardUpTo(hi immutable_rb_tree.Bound[Key]) iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.rbTree().BackwardUpTo(kvPairBound[Value](hi)))
+}
+
 func (m *Map[Key, Value]) KeyValuePairs() []tuple.KeyValuePair[Key, Value] {
 	return m.rbTree().Values()
 }
@@ -88,6 +236,125 @@ func (m *Map[Key, Value]) Delete(key Key) (newMap *Map[Key, Value], affected boo
 	return (*Map[Key, Value])(newRBTree), affected
 }
 
+// `Update(key, f)` replaces the value of `key` with `f(value)` in a single traversal.
+// `affected` is true, meaning that `key` exists, `newMap` will be different from the original;
+// otherwise nothing happens, `newMap` is the original one.
+func (m *Map[Key, Value]) Update(key Key, f func(Value) Value) (newMap *Map[Key, Value], affected bool) {
+	newMap, old := m.Alter(key, func(old maybe.Maybe[Value]) maybe.Maybe[Value] {
+		return maybe.Map(old, f)
+	})
+	return newMap, old.IsJust()
+}
+
+// `Upsert(key, f)` sets the value of `key` to `f(old)` in a single traversal, where `old` is the current value of `key` (if any).
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+func (m *Map[Key, Value]) Upsert(key Key, f func(old maybe.Maybe[Value]) Value) (newMap *Map[Key, Value], affected bool) {
+	newMap, old := m.Alter(key, func(old maybe.Maybe[Value]) maybe.Maybe[Value] {
+		return maybe.Just(f(old))
+	})
+	return newMap, old.IsNothing()
+}
+
+// `Alter(key, f)` passes the current value of `key` (if any) to `f` in a single traversal,
+// `key` is then set to the `Just` value returned by `f`, or deleted if `f` returns `Nothing`.
+// `old` is the value of `key` before alteration; `newMap` is the original one if nothing happens.
+func (m *Map[Key, Value]) Alter(key Key, f func(old maybe.Maybe[Value]) maybe.Maybe[Value]) (newMap *Map[Key, Value], old maybe.Maybe[Value]) {
+
+	newRBTree, oldKVPair := m.rbTree().Alter(
+		tuple.KeyValuePair[Key, Value]{
+			Key: key,
+		},
+		kvPairAlteration(key, f),
+	)
+
+	return (*Map[Key, Value])(newRBTree), maybe.Map(oldKVPair, kvPairValue[Key, Value])
+}
+
+// `Merge(other, resolve)` returns a map of key-value pairs in either `m` or `other`,
+// values of keys in both are resolved by `resolve`.
+// CAUTION: Only invoke `Merge` with maps sharing the same comparator, likewise for the other combinations.
+func (m *Map[Key, Value]) Merge(other *Map[Key, Value], resolve func(key Key, left Value, right Value) Value) *Map[Key, Value] {
+	return (*Map[Key, Value])(m.rbTree().UnionWith(
+		other.rbTree(),
+		func(l tuple.KeyValuePair[Key, Value], r tuple.KeyValuePair[Key, Value]) tuple.KeyValuePair[Key, Value] {
+			return tuple.KeyValuePair[Key, Value]{
+				Key:   l.Key,
+				Value: resolve(l.Key, l.Value, r.Value),
+			}
+		},
+	))
+}
+
+// `UnionWith(other, resolve)` is like `Merge(other, resolve)` but `resolve` ignores the key.
+func (m *Map[Key, Value]) UnionWith(other *Map[Key, Value], resolve func(left Value, right Value) Value) *Map[Key, Value] {
+	return m.Merge(other, func(_ Key, left Value, right Value) Value {
+		return resolve(left, right)
+	})
+}
+
+// `IntersectionWith(other, resolve)` returns a map of keys in both `m` and `other`, whose values are resolved by `resolve`.
+func (m *Map[Key, Value]) IntersectionWith(other *Map[Key, Value], resolve func(left Value, right Value) Value) *Map[Key, Value] {
+	return (*Map[Key, Value])(m.rbTree().IntersectionWith(
+		other.rbTree(),
+		func(l tuple.KeyValuePair[Key, Value], r tuple.KeyValuePair[Key, Value]) tuple.KeyValuePair[Key, Value] {
+			return tuple.KeyValuePair[Key, Value]{
+				Key:   l.Key,
+				Value: resolve(l.Value, r.Value),
+			}
+		},
+	))
+}
+
+// `DifferenceBy(other, resolve)` returns a map of key-value pairs in `m` whose keys are not in `other`,
+// for keys in both, the pair is kept with the new value if `resolve` returns a `Just` value, otherwise it is dropped.
+func (m *Map[Key, Value]) DifferenceBy(other *Map[Key, Value], resolve func(left Value, right Value) maybe.Maybe[Value]) *Map[Key, Value] {
+	return (*Map[Key, Value])(m.rbTree().DifferenceWith(
+		other.rbTree(),
+		func(l tuple.KeyValuePair[Key, Value], r tuple.KeyValuePair[Key, Value]) maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+			return maybe.Map(resolve(l.Value, r.Value), func(value Value) tuple.KeyValuePair[Key, Value] {
+				return tuple.KeyValuePair[Key, Value]{
+					Key:   l.Key,
+					Value: value,
+				}
+			})
+		},
+	))
+}
+
+func kvPairComparator[Value any, Key any](cmp comparator.Comparator[Key]) comparator.Comparator[tuple.KeyValuePair[Key, Value]] {
+	return func(l tuple.KeyValuePair[Key, Value], r tuple.KeyValuePair[Key, Value]) int {
+		return cmp(l.Key, r.Key)
+	}
+}
+
+// `kvPairAlteration()` lifts the alteration `f` of values of `key` to the one of key-value pairs.
+func kvPairAlteration[Key any, Value any](key Key, f func(maybe.Maybe[Value]) maybe.Maybe[Value]) func(maybe.Maybe[tuple.KeyValuePair[Key, Value]]) maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+	return func(old maybe.Maybe[tuple.KeyValuePair[Key, Value]]) maybe.Maybe[tuple.KeyValuePair[Key, Value]] {
+		return maybe.Map(
+			f(maybe.Map(old, kvPairValue[Key, Value])),
+			func(value Value) tuple.KeyValuePair[Key, Value] {
+				return tuple.KeyValuePair[Key, Value]{
+					Key:   key,
+					Value: value,
+				}
+			},
+		)
+	}
+}
+
+func kvPairValue[Key any, Value any](kvPair tuple.KeyValuePair[Key, Value]) Value {
+	return kvPair.Value
+}
+
+func kvPairBound[Value any, Key any](bound immutable_rb_tree.Bound[Key]) immutable_rb_tree.Bound[tuple.KeyValuePair[Key, Value]] {
+	return immutable_rb_tree.Bound[tuple.KeyValuePair[Key, Value]]{
+		Value: tuple.KeyValuePair[Key, Value]{
+			Key: bound.Value,
+		},
+		Inclusive: bound.Inclusive,
+	}
+}
+
 func (m *Map[Key, Value]) rbTree() *immutable_rb_tree.RBTree[tuple.KeyValuePair[Key, Value]] {
 	return (*immutable_rb_tree.RBTree[tuple.KeyValuePair[Key, Value]])(m)
 }
@@ -1,12 +1,16 @@
 package immutable_map
 
 import (
+	"reflect"
 	"slices"
 	"strconv"
 	"testing"
 
 	"github.com/freebirdljj/immutable/comparator"
 	"github.com/freebirdljj/immutable/internal/quick"
+	immutable_iter "github.com/freebirdljj/immutable/iter"
+	"github.com/freebirdljj/immutable/maybe"
+	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
 	"github.com/freebirdljj/immutable/tuple"
 )
 
@@ -87,3 +91,459 @@ func TestMapDelete(t *testing.T) {
 		},
 	})
 }
+
+func TestMapAt(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.at(k) == m.keyValuePairs()[k]": func(xs []int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, x := range xs {
+				m, _ = m.Insert(x, strconv.Itoa(x))
+			}
+
+			for k, kvPair := range m.KeyValuePairs() {
+				key, value, has := m.At(k)
+				if !has || key != kvPair.Key || value != kvPair.Value {
+					return false
+				}
+			}
+
+			_, _, has := m.At(m.Count())
+			return !has
+		},
+	})
+}
+
+func TestMapRank(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.rank(key) == len(filter(< key, m.keys()))": func(xs []int, x int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			expected := 0
+			for _, kvPair := range m.KeyValuePairs() {
+				if kvPair.Key < x {
+					expected++
+				}
+			}
+			return m.Rank(x) == expected && m.CountRange(x, x) == 0
+		},
+	})
+}
+
+func TestMapRange(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.range(lo, hi) == filter(in range, m.keyValuePairs())": func(xs []int, lo int, hi int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			expected := []tuple.KeyValuePair[int, string](nil)
+			for _, kvPair := range m.KeyValuePairs() {
+				if lo < kvPair.Key && kvPair.Key <= hi {
+					expected = append(expected, kvPair)
+				}
+			}
+
+			return slices.Equal(
+				slices.Collect(immutable_iter.SeqFromSeq2(m.Range(immutable_rb_tree.Exclusive(lo), immutable_rb_tree.Inclusive(hi)))),
+				expected,
+			)
+		},
+		"m.upTo(hi) ++ m.from(hi) == m.keyValuePairs() if bounds are complementary": func(xs []int, hi int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			return slices.Equal(
+				append(
+					slices.Collect(immutable_iter.SeqFromSeq2(m.UpTo(immutable_rb_tree.Inclusive(hi)))),
+					slices.Collect(immutable_iter.SeqFromSeq2(m.From(immutable_rb_tree.Exclusive(hi))))...,
+				),
+				m.KeyValuePairs(),
+			)
+		},
+	})
+}
+
+func TestMapBackward(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.backward() == reverse(m.keyValuePairs())": func(xs []int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			kvPairs := m.KeyValuePairs()
+			slices.Reverse(kvPairs)
+			return slices.Equal(slices.Collect(immutable_iter.SeqFromSeq2(m.Backward())), kvPairs)
+		},
+		"m.backwardRange(lo, hi) == reverse(m.range(lo, hi))": func(xs []int, lo int, hi int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			kvPairs := slices.Collect(immutable_iter.SeqFromSeq2(m.Range(immutable_rb_tree.Inclusive(lo), immutable_rb_tree.Inclusive(hi))))
+			slices.Reverse(kvPairs)
+			return slices.Equal(
+				slices.Collect(immutable_iter.SeqFromSeq2(m.BackwardRange(immutable_rb_tree.Inclusive(lo), immutable_rb_tree.Inclusive(hi)))),
+				kvPairs,
+			)
+		},
+	})
+}
+
+func TestMapFloorAndCeiling(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.floor(key) should be the last pair of m.upTo(inclusive(key))": func(xs []int, x int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			kvPairs := slices.Collect(immutable_iter.SeqFromSeq2(m.BackwardUpTo(immutable_rb_tree.Inclusive(x))))
+			floor := m.Floor(x)
+			if len(kvPairs) == 0 {
+				return floor.IsNothing()
+			}
+			return floor.IsJust() && floor.Value() == kvPairs[0]
+		},
+		"m.higher(key) should be the first pair of m.from(exclusive(key))": func(xs []int, x int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			kvPairs := slices.Collect(immutable_iter.SeqFromSeq2(m.From(immutable_rb_tree.Exclusive(x))))
+			higher := m.Higher(x)
+			if len(kvPairs) == 0 {
+				return higher.IsNothing()
+			}
+			return higher.IsJust() && higher.Value() == kvPairs[0]
+		},
+		"m.lower(key) and m.ceiling(key) should be adjacent": func(xs []int, x int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+
+			lower, ceiling := m.Lower(x), m.Ceiling(x)
+			return (lower.IsNothing() || m.Rank(lower.Value().Key)+1 == m.Rank(x)) &&
+				(ceiling.IsNothing() || m.Rank(ceiling.Value().Key) == m.Rank(x))
+		},
+	})
+}
+
+func TestMapPopMax(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"popping max repeatedly drains m in descending order of keys": func(xs []int) bool {
+
+			m := New[int, string](comparator.OrderedComparator[int])
+			for _, value := range xs {
+				m, _ = m.Insert(value, strconv.Itoa(value))
+			}
+			kvPairs := m.KeyValuePairs()
+
+			popped := []tuple.KeyValuePair[int, string](nil)
+			for max := m.Max(); max.IsJust(); max = m.Max() {
+				newM, popMax := m.PopMax()
+				if popMax.Value() != max.Value() {
+					return false
+				}
+				popped = append(popped, popMax.Value())
+				m = newM
+			}
+
+			slices.Reverse(popped)
+			return m.Empty() && m.Min().IsNothing() && slices.Equal(popped, kvPairs)
+		},
+	})
+}
+
+func TestMapMerge(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"merge(xs, ys, resolve) == toGoMap(xs) merged with toGoMap(ys) by resolve": func(xs map[int8]int, ys map[int8]int) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+			resolve := func(key int8, left int, right int) int { return int(key) + left - right }
+
+			expected := make(map[int8]int, len(xs)+len(ys))
+			for k, v := range ys {
+				expected[k] = v
+			}
+			for k, v := range xs {
+				if right, has := ys[k]; has {
+					expected[k] = resolve(k, v, right)
+				} else {
+					expected[k] = v
+				}
+			}
+
+			merged := FromGoMap(cmp, xs).Merge(FromGoMap(cmp, ys), resolve)
+			return reflect.DeepEqual(ToGoMap(merged), expected)
+		},
+		"unionWith(xs, xs, resolve) applies resolve to every value": func(xs map[int8]int) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+			m := FromGoMap(cmp, xs)
+
+			for k, v := range m.UnionWith(m, func(left int, right int) int { return left + right }).All() {
+				if v != 2*xs[k] {
+					return false
+				}
+			}
+			return true
+		},
+	})
+}
+
+func TestMapIntersectionWith(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"intersectionWith(xs, ys, resolve) only holds common keys with resolved values": func(xs map[int8]int, ys map[int8]int) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+
+			expected := make(map[int8]int)
+			for k, v := range xs {
+				if right, has := ys[k]; has {
+					expected[k] = v * right
+				}
+			}
+
+			intersection := FromGoMap(cmp, xs).IntersectionWith(FromGoMap(cmp, ys), func(left int, right int) int { return left * right })
+			return reflect.DeepEqual(ToGoMap(intersection), expected)
+		},
+	})
+}
+
+func TestMapDifferenceBy(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"differenceBy(xs, ys, resolve) drops common keys unless resolved to a `Just` value": func(xs map[int8]int, ys map[int8]int) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+			resolve := func(left int, right int) maybe.Maybe[int] {
+				if left < right {
+					return maybe.Nothing[int]()
+				}
+				return maybe.Just(left - right)
+			}
+
+			expected := make(map[int8]int)
+			for k, v := range xs {
+				right, has := ys[k]
+				if !has {
+					expected[k] = v
+				} else if resolved := resolve(v, right); resolved.IsJust() {
+					expected[k] = resolved.Value()
+				}
+			}
+
+			difference := FromGoMap(cmp, xs).DifferenceBy(FromGoMap(cmp, ys), resolve)
+			return reflect.DeepEqual(ToGoMap(difference), expected)
+		},
+	})
+}
+
+func TestMapUpdate(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.update(key, f).index(key) == f(m.index(key)) if m has key": func(xs map[int]int, x int, v int) bool {
+
+			m, _ := FromGoMap(comparator.OrderedComparator[int], xs).Insert(x, v)
+
+			newM, affected := m.Update(x, func(value int) int { return value + 1 })
+			newV, has := newM.Index(x)
+			return affected && has && newV == v+1 && newM.Count() == m.Count()
+		},
+		"m.update(key, f) === m if m doesn't have key": func(xs map[int]int, x int) bool {
+
+			m, _ := FromGoMap(comparator.OrderedComparator[int], xs).Delete(x)
+
+			newM, affected := m.Update(x, func(value int) int { return value + 1 })
+			return !affected && newM == m
+		},
+	})
+}
+
+func TestMapUpsert(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.upsert(key, f) counts occurrences": func(xs []int8) bool {
+
+			m := New[int8, int](comparator.OrderedComparator[int8])
+			expected := make(map[int8]int)
+
+			for _, x := range xs {
+				_, existed := expected[x]
+				expected[x]++
+
+				newM, affected := m.Upsert(x, func(old maybe.Maybe[int]) int { return old.OrValue(0) + 1 })
+				if affected == existed {
+					return false
+				}
+				m = newM
+			}
+
+			return reflect.DeepEqual(ToGoMap(m), expected)
+		},
+	})
+}
+
+func TestMapAlter(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.alter(key, f) behaves like index, then insert or delete": func(xs map[int8]int, x int8, v int, keep bool) bool {
+
+			m := FromGoMap(comparator.OrderedComparator[int8], xs)
+			f := func(old maybe.Maybe[int]) maybe.Maybe[int] {
+				if !keep {
+					return maybe.Nothing[int]()
+				}
+				return maybe.Just(old.OrValue(0) + v)
+			}
+
+			oldV, has := m.Index(x)
+			expected := m
+			if keep {
+				expected, _ = m.Insert(x, oldV+v)
+			} else {
+				expected, _ = m.Delete(x)
+			}
+
+			newM, old := m.Alter(x, f)
+			return old.IsJust() == has &&
+				old.OrValue(0) == oldV &&
+				slices.Equal(newM.KeyValuePairs(), expected.KeyValuePairs()) &&
+				(keep || has || newM == m)
+		},
+		"altering every key to nothing makes m empty": func(xs map[int8]int) bool {
+
+			m := FromGoMap(comparator.OrderedComparator[int8], xs)
+
+			for k := range xs {
+				m, _ = m.Alter(k, func(maybe.Maybe[int]) maybe.Maybe[int] { return maybe.Nothing[int]() })
+			}
+
+			return m.Empty() && m.Count() == 0
+		},
+	})
+}
+
+func TestMapDiff(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"applying diff(xs, ys) to xs should result in ys": func(xs map[int8]int8, ys map[int8]int8) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+			xMap, yMap := FromGoMap(cmp, xs), FromGoMap(cmp, ys)
+
+			m := xMap
+			for key, change := range Diff(xMap, yMap) {
+				if change.New.IsJust() {
+					m, _ = m.Insert(key, change.New.Value())
+				} else {
+					m, _ = m.Delete(key)
+				}
+			}
+
+			return reflect.DeepEqual(ToGoMap(m), ToGoMap(yMap))
+		},
+		"diff(xs, ys) should only report keys whose values differ": func(xs map[int8]int8, ys map[int8]int8) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+
+			for key, change := range Diff(FromGoMap(cmp, xs), FromGoMap(cmp, ys)) {
+				old, hasOld := xs[key]
+				value, hasNew := ys[key]
+				if change.Old.IsJust() != hasOld || change.New.IsJust() != hasNew ||
+					change.Old.OrValue(old) != old || change.New.OrValue(value) != value ||
+					(hasOld && hasNew && old == value) {
+					return false
+				}
+			}
+			return true
+		},
+	})
+}
+
+func TestMapFromSortedSeq(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"toGoMap(fromSortedSeq(m.all())) == toGoMap(m)": func(xs map[int]string) bool {
+
+			cmp := comparator.OrderedComparator[int]
+			m := FromGoMap(cmp, xs)
+
+			return reflect.DeepEqual(ToGoMap(FromSortedSeq(cmp, m.All())), xs) &&
+				slices.Equal(FromSortedKeyValuePairs(cmp, m.KeyValuePairs()...).KeyValuePairs(), m.KeyValuePairs())
+		},
+		"fromKeyValuePairs(kvPairs) should preserve the last value among equal keys": func(keys []int8, values []int) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+
+			kvPairs := make([]tuple.KeyValuePair[int8, int], 0, len(keys))
+			expected := make(map[int8]int, len(keys))
+			for i, key := range keys {
+				value := i
+				if i < len(values) {
+					value = values[i]
+				}
+				kvPairs = append(kvPairs, tuple.KeyValuePair[int8, int]{Key: key, Value: value})
+				expected[key] = value
+			}
+
+			return reflect.DeepEqual(ToGoMap(FromKeyValuePairs(cmp, kvPairs...)), expected)
+		},
+	})
+}
+
+func TestSummarizedMap(t *testing.T) {
+
+	monoid := immutable_rb_tree.Monoid[tuple.KeyValuePair[string, int8], int]{
+		Empty:   0,
+		Combine: func(l int, r int) int { return l + r },
+		Measure: func(kvPair tuple.KeyValuePair[string, int8]) int { return int(kvPair.Value) },
+	}
+
+	quick.CheckProperties(t, map[string]any{
+		"fold(lo, hi) == sum of values in range(lo, hi)": func(goMap map[string]int8, ys []string, lo string, hi string) bool {
+
+			m := Summarize(FromGoMap(comparator.OrderedComparator[string], goMap), monoid)
+			for _, y := range ys {
+				m, _ = m.Insert(y, 1)
+			}
+
+			expected := 0
+			for _, value := range m.Range(immutable_rb_tree.Inclusive(lo), immutable_rb_tree.Exclusive(hi)) {
+				expected += int(value)
+			}
+			return m.Fold(immutable_rb_tree.Inclusive(lo), immutable_rb_tree.Exclusive(hi)) == expected
+		},
+		"summary() == sum of all values after alterations": func(goMap map[string]int8, ys []string) bool {
+
+			m := Summarize(FromGoMap(comparator.OrderedComparator[string], goMap), monoid)
+			for _, y := range ys {
+				m, _ = m.Alter(y, func(old maybe.Maybe[int8]) maybe.Maybe[int8] {
+					if old.IsJust() {
+						return maybe.Nothing[int8]()
+					}
+					return maybe.Just(int8(len(y)))
+				})
+			}
+
+			expected := 0
+			for _, value := range m.All() {
+				expected += int(value)
+			}
+			return m.Summary() == expected
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package immutable_map
+
+import (
+	"iter"
+
+	immutable_func "github.com/freebirdljj/immutable/func"
+	immutable_iter "github.com/freebirdljj/immutable/iter"
+	"github.com/freebirdljj/immutable/maybe"
+	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+type (
+	// `Summarized` is a `Map` whose key-value pairs are summarized by a monoid, which makes `Fold()` over key ranges O(log n).
+	Summarized[Key any, Value any, Summary any] immutable_rb_tree.Summarized[tuple.KeyValuePair[Key, Value], Summary]
+)
+
+// `Summarize(m, monoid)` returns a map equal to `m` whose key-value pairs are summarized by `monoid` in O(n),
+// the summaries are kept up to date by later operations.
+func Summarize[Key any, Value any, Summary any](m *Map[Key, Value], monoid immutable_rb_tree.Monoid[tuple.KeyValuePair[Key, Value], Summary]) *Summarized[Key, Value, Summary] {
+	return (*Summarized[Key, Value, Summary])(immutable_rb_tree.Summarize(m.rbTree(), monoid))
+}
+
+func (m *Summarized[Key, Value, Summary]) Empty() bool {
+	return m.summarized().Empty()
+}
+
+func (m *Summarized[Key, Value, Summary]) Count() int {
+	return m.summarized().Count()
+}
+
+func (m *Summarized[Key, Value, Summary]) Index(key Key) (value Value, has bool) {
+	kv := m.summarized().Lookup(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+	if kv == nil {
+		return immutable_func.Zero[Value](), false
+	}
+	return kv.Value, true
+}
+
+func (m *Summarized[Key, Value, Summary]) All() iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.summarized().All())
+}
+
+// `Range(lo, hi)` returns an iterator of all key-value pairs whose keys are between `lo` and `hi` in ascending order.
+func (m *Summarized[Key, Value, Summary]) Range(lo immutable_rb_tree.Bound[Key], hi immutable_rb_tree.Bound[Key]) iter.Seq2[Key, Value] {
+	return immutable_iter.Seq2FromSeq(m.summarized().Range(kvPairBound[Value](lo), kvPairBound[Value](hi)))
+}
+
+// `Summary()` returns the summary of all key-value pairs in O(1).
+func (m *Summarized[Key, Value, Summary]) Summary() Summary {
+	return m.summarized().Summary()
+}
+
+// `Fold(lo, hi)` returns the summary of key-value pairs whose keys are between `lo` and `hi` in O(log n).
+func (m *Summarized[Key, Value, Summary]) Fold(lo immutable_rb_tree.Bound[Key], hi immutable_rb_tree.Bound[Key]) Summary {
+	return m.summarized().Fold(kvPairBound[Value](lo), kvPairBound[Value](hi))
+}
+
+func (m *Summarized[Key, Value, Summary]) Insert(key Key, value Value) (newMap *Summarized[Key, Value, Summary], affected bool) {
+	newSummarized, affected := m.summarized().Insert(tuple.KeyValuePair[Key, Value]{
+		Key:   key,
+		Value: value,
+	})
+	return (*Summarized[Key, Value, Summary])(newSummarized), affected
+}
+
+func (m *Summarized[Key, Value, Summary]) Delete(key Key) (newMap *Summarized[Key, Value, Summary], affected bool) {
+	newSummarized, affected := m.summarized().Delete(tuple.KeyValuePair[Key, Value]{
+		Key: key,
+	})
+	return (*Summarized[Key, Value, Summary])(newSummarized), affected
+}
+
+// `Alter(key, f)` is the summarized counterpart of `Map.Alter(key, f)`.
+func (m *Summarized[Key, Value, Summary]) Alter(key Key, f func(old maybe.Maybe[Value]) maybe.Maybe[Value]) (newMap *Summarized[Key, Value, Summary], old maybe.Maybe[Value]) {
+
+	newSummarized, oldKVPair := m.summarized().Alter(
+		tuple.KeyValuePair[Key, Value]{
+			Key: key,
+		},
+		kvPairAlteration(key, f),
+	)
+
+	return (*Summarized[Key, Value, Summary])(newSummarized), maybe.Map(oldKVPair, kvPairValue[Key, Value])
+}
+
+func (m *Summarized[Key, Value, Summary]) summarized() *immutable_rb_tree.Summarized[tuple.KeyValuePair[Key, Value], Summary] {
+	return (*immutable_rb_tree.Summarized[tuple.KeyValuePair[Key, Value], Summary])(m)
+}
@@ -0,0 +1,181 @@
+package immutable_multimap
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/comparator"
+	immutable_iter "github.com/freebirdljj/immutable/iter"
+	immutable_map "github.com/freebirdljj/immutable/map"
+	"github.com/freebirdljj/immutable/maybe"
+	immutable_set "github.com/freebirdljj/immutable/set"
+)
+
+type (
+	// The zero value of `MultiMap` makes nonsense.
+	// A multimap maps each key to a non-empty set of entries, each of which is a value tagged with the order it was added,
+	// so every value added is kept, even if it is equal to others by `valueCmp`.
+	MultiMap[Key any, Value any] struct {
+		entries  *immutable_map.Map[Key, *immutable_set.Set[entry[Value]]]
+		valueCmp comparator.Comparator[Value]
+		seq      uint64 // the order of the next value to be added
+		size     int
+	}
+
+	entry[Value any] struct {
+		value Value
+		seq   uint64
+	}
+)
+
+// `valueCmp` only orders values of each key, values equal by it are ordered by when they were added.
+func New[Key any, Value any](keyCmp comparator.Comparator[Key], valueCmp comparator.Comparator[Value]) *MultiMap[Key, Value] {
+	return &MultiMap[Key, Value]{
+		entries:  immutable_map.New[Key, *immutable_set.Set[entry[Value]]](keyCmp),
+		valueCmp: valueCmp,
+	}
+}
+
+func (m *MultiMap[Key, Value]) Empty() bool {
+	return m.size == 0
+}
+
+// `Len()` returns the number of key-value pairs.
+func (m *MultiMap[Key, Value]) Len() int {
+	return m.size
+}
+
+// `CountKeys()` returns the number of distinct keys.
+func (m *MultiMap[Key, Value]) CountKeys() int {
+	return m.entries.Count()
+}
+
+// `Count(key)` returns the number of values of `key`, which is 0 if absent.
+func (m *MultiMap[Key, Value]) Count(key Key) int {
+	entries, has := m.entries.Index(key)
+	if !has {
+		return 0
+	}
+	return entries.Count()
+}
+
+// `Get(key)` returns an iterator of all values of `key` in ascending order,
+// values equal by `valueCmp` are in the order they were added.
+func (m *MultiMap[Key, Value]) Get(key Key) iter.Seq[Value] {
+	entries, has := m.entries.Index(key)
+	if !has {
+		return immutable_iter.Empty[Value]()
+	}
+	return immutable_iter.Map(entries.All(), entryValue[Value])
+}
+
+// `Add(key, value)` returns a multimap with `value` added to the values of `key`.
+func (m *MultiMap[Key, Value]) Add(key Key, value Value) *MultiMap[Key, Value] {
+	newEntries, _ := m.entries.Alter(key, func(old maybe.Maybe[*immutable_set.Set[entry[Value]]]) maybe.Maybe[*immutable_set.Set[entry[Value]]] {
+		newEntry := entry[Value]{
+			value: value,
+			seq:   m.seq,
+		}
+		if old.IsNothing() {
+			return maybe.Just(immutable_set.FromValues(entryComparator(m.valueCmp), newEntry))
+		}
+		newSet, _ := old.Value().Insert(newEntry)
+		return maybe.Just(newSet)
+	})
+	return m.with(newEntries, m.seq+1, m.size+1)
+}
+
+// `RemoveOne(key, value)` returns a multimap with the earliest added value equal to `value` removed from the values of `key`.
+// `affected` is true, meaning that such a value exists; otherwise nothing happens, `newMultiMap` is the original one.
+func (m *MultiMap[Key, Value]) RemoveOne(key Key, value Value) (newMultiMap *MultiMap[Key, Value], affected bool) {
+
+	newEntries, _ := m.entries.Alter(key, func(old maybe.Maybe[*immutable_set.Set[entry[Value]]]) maybe.Maybe[*immutable_set.Set[entry[Value]]] {
+		return maybe.Bind(old, func(entries *immutable_set.Set[entry[Value]]) maybe.Maybe[*immutable_set.Set[entry[Value]]] {
+
+			earliest := entries.Ceiling(entry[Value]{value: value, seq: 0})
+			if earliest.IsNothing() || m.valueCmp(earliest.Value().value, value) != 0 {
+				return old
+			}
+
+			affected = true
+			newSet, _ := entries.Delete(earliest.Value())
+			if newSet.Empty() {
+				return maybe.Nothing[*immutable_set.Set[entry[Value]]]()
+			}
+			return maybe.Just(newSet)
+		})
+	})
+
+	if !affected {
+		return m, false
+	}
+	return m.with(newEntries, m.seq, m.size-1), true
+}
+
+// `RemoveAll(key)` returns a multimap without `key`, together with the number of values `key` had.
+// Nothing happens if `removed` is 0, `newMultiMap` is the original one.
+func (m *MultiMap[Key, Value]) RemoveAll(key Key) (newMultiMap *MultiMap[Key, Value], removed int) {
+
+	newEntries, old := m.entries.Alter(key, func(maybe.Maybe[*immutable_set.Set[entry[Value]]]) maybe.Maybe[*immutable_set.Set[entry[Value]]] {
+		return maybe.Nothing[*immutable_set.Set[entry[Value]]]()
+	})
+
+	if old.IsNothing() {
+		return m, 0
+	}
+	return m.with(newEntries, m.seq, m.size-old.Value().Count()), old.Value().Count()
+}
+
+// `Keys()` returns an iterator of all distinct keys with their numbers of values in ascending order.
+func (m *MultiMap[Key, Value]) Keys() iter.Seq2[Key, int] {
+	return func(yield func(Key, int) bool) {
+		for key, entries := range m.entries.All() {
+			if !yield(key, entries.Count()) {
+				return
+			}
+		}
+	}
+}
+
+// `All()` returns an iterator of all key-value pairs in ascending order of keys and then of values,
+// values equal by `valueCmp` are in the order they were added.
+func (m *MultiMap[Key, Value]) All() iter.Seq2[Key, Value] {
+	return func(yield func(Key, Value) bool) {
+		for key, entries := range m.entries.All() {
+			for e := range entries.All() {
+				if !yield(key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (m *MultiMap[Key, Value]) with(entries *immutable_map.Map[Key, *immutable_set.Set[entry[Value]]], seq uint64, size int) *MultiMap[Key, Value] {
+	return &MultiMap[Key, Value]{
+		entries:  entries,
+		valueCmp: m.valueCmp,
+		seq:      seq,
+		size:     size,
+	}
+}
+
+// NOTE: Entries of a multimap never share `seq`, since it only grows along the versions they are added to.
+func entryComparator[Value any](valueCmp comparator.Comparator[Value]) comparator.Comparator[entry[Value]] {
+	return func(l entry[Value], r entry[Value]) int {
+		if c := valueCmp(l.value, r.value); c != 0 {
+			return c
+		}
+		switch {
+		case l.seq < r.seq:
+			return -1
+		case l.seq > r.seq:
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+func entryValue[Value any](e entry[Value]) Value {
+	return e.value
+}
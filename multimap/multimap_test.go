@@ -0,0 +1,144 @@
+package immutable_multimap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/tuple"
+)
+
+func fromKeyValuePairs(kvPairs []tuple.KeyValuePair[int8, int8]) *MultiMap[int8, int8] {
+	m := New[int8, int8](comparator.OrderedComparator[int8], comparator.OrderedComparator[int8])
+	for _, kvPair := range kvPairs {
+		m = m.Add(kvPair.Key, kvPair.Value)
+	}
+	return m
+}
+
+// `valuesOf()` returns all values of `key` in `kvPairs` in ascending order as a reference implementation.
+func valuesOf(kvPairs []tuple.KeyValuePair[int8, int8], key int8) []int8 {
+	values := []int8(nil)
+	for _, kvPair := range kvPairs {
+		if kvPair.Key == key {
+			values = append(values, kvPair.Value)
+		}
+	}
+	slices.Sort(values)
+	return values
+}
+
+func TestMultiMap(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"m.get(k) should yield all values added to k": func(kvPairs []tuple.KeyValuePair[int8, int8], k int8) bool {
+
+			m := fromKeyValuePairs(kvPairs)
+			values := valuesOf(kvPairs, k)
+
+			return slices.Equal(slices.Collect(m.Get(k)), values) && m.Count(k) == len(values) && m.Len() == len(kvPairs)
+		},
+		"m.all() should be ordered by keys and then by values": func(kvPairs []tuple.KeyValuePair[int8, int8]) bool {
+
+			m := fromKeyValuePairs(kvPairs)
+
+			all := []tuple.KeyValuePair[int8, int8](nil)
+			for k, v := range m.All() {
+				all = append(all, tuple.KeyValuePair[int8, int8]{Key: k, Value: v})
+			}
+
+			expected := slices.SortedFunc(slices.Values(kvPairs), func(l tuple.KeyValuePair[int8, int8], r tuple.KeyValuePair[int8, int8]) int {
+				if l.Key != r.Key {
+					return int(l.Key) - int(r.Key)
+				}
+				return int(l.Value) - int(r.Value)
+			})
+
+			keys := 0
+			for k, n := range m.Keys() {
+				if n != len(valuesOf(kvPairs, k)) {
+					return false
+				}
+				keys++
+			}
+
+			return slices.Equal(all, expected) && keys == m.CountKeys()
+		},
+		"m.removeOne(k, v) should remove exactly one v from k": func(kvPairs []tuple.KeyValuePair[int8, int8], k int8, v int8) bool {
+
+			m := fromKeyValuePairs(kvPairs)
+			values := valuesOf(kvPairs, k)
+
+			newM, affected := m.RemoveOne(k, v)
+			if i := slices.Index(values, v); i >= 0 {
+				values = slices.Delete(values, i, i+1)
+			}
+
+			return slices.Equal(slices.Collect(newM.Get(k)), values) &&
+				affected == slices.Contains(valuesOf(kvPairs, k), v) &&
+				newM.Len() == m.Len()-btoi(affected) &&
+				(affected || newM == m)
+		},
+		"m.removeAll(k) should remove k entirely": func(kvPairs []tuple.KeyValuePair[int8, int8], k int8) bool {
+
+			m := fromKeyValuePairs(kvPairs)
+
+			newM, removed := m.RemoveAll(k)
+			return newM.Count(k) == 0 &&
+				removed == m.Count(k) &&
+				newM.Len() == m.Len()-removed &&
+				newM.CountKeys() == m.CountKeys()-btoi(removed > 0)
+		},
+	})
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestMultiMapWithCoarseComparator(t *testing.T) {
+
+	type task struct {
+		prio int8
+		name int8
+	}
+
+	// NOTE: Tasks of the same priority are equal by `prioCmp`, though they are distinct.
+	prioCmp := comparator.CascadeComparator(comparator.OrderedComparator[int8], func(t task) int8 { return t.prio % 4 })
+
+	quick.CheckProperties(t, map[string]any{
+		"m.get(k) should keep every value added to k, equal ones in the order added": func(prios []int8) bool {
+
+			m := New[string](comparator.OrderedComparator[string], prioCmp)
+			tasks := []task(nil)
+			for i, prio := range prios {
+				tasks = append(tasks, task{prio: prio, name: int8(i)})
+				m = m.Add("k", tasks[i])
+			}
+
+			slices.SortStableFunc(tasks, prioCmp)
+			return slices.Equal(slices.Collect(m.Get("k")), tasks)
+		},
+		"m.removeOne(k, v) should remove the earliest added value equal to v": func(prios []int8, prio int8) bool {
+
+			m := New[string](comparator.OrderedComparator[string], prioCmp)
+			tasks := []task(nil)
+			for i, prio := range prios {
+				tasks = append(tasks, task{prio: prio, name: int8(i)})
+				m = m.Add("k", tasks[i])
+			}
+
+			newM, affected := m.RemoveOne("k", task{prio: prio})
+			i := slices.IndexFunc(tasks, func(t task) bool { return prioCmp(t, task{prio: prio}) == 0 })
+			if i >= 0 {
+				tasks = slices.Delete(tasks, i, i+1)
+			}
+
+			slices.SortStableFunc(tasks, prioCmp)
+			return affected == (i >= 0) && slices.Equal(slices.Collect(newM.Get("k")), tasks)
+		},
+	})
+}
@@ -0,0 +1,114 @@
+package immutable_rb_tree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+)
+
+const benchmarkSize = 1 << 16
+
+func benchmarkValues() []int {
+	return rand.New(rand.NewSource(0)).Perm(benchmarkSize)
+}
+
+func BenchmarkRBTreeInsert(b *testing.B) {
+
+	values := benchmarkValues()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		rbTree := New(comparator.OrderedComparator[int])
+		for _, value := range values {
+			rbTree, _ = rbTree.Insert(value)
+		}
+	}
+}
+
+func BenchmarkRBTreeDelete(b *testing.B) {
+
+	values := benchmarkValues()
+	rbTree := FromValues(comparator.OrderedComparator[int], values...)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		newTree := rbTree
+		for _, value := range values {
+			newTree, _ = newTree.Delete(value)
+		}
+	}
+}
+
+func BenchmarkRBTreeLookup(b *testing.B) {
+
+	values := benchmarkValues()
+	rbTree := FromValues(comparator.OrderedComparator[int], values...)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, value := range values {
+			rbTree.Lookup(value)
+		}
+	}
+}
+
+func BenchmarkRBTreeAll(b *testing.B) {
+
+	rbTree := FromValues(comparator.OrderedComparator[int], benchmarkValues()...)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for range rbTree.All() {
+		}
+	}
+}
+
+func BenchmarkRBTreeEqualSharing(b *testing.B) {
+
+	values := benchmarkValues()
+	rbTree := FromValues(comparator.OrderedComparator[int], values...)
+	newTree, _ := rbTree.Delete(values[0])
+	newTree, _ = newTree.Insert(values[0])
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if !rbTree.Equal(newTree) {
+			b.Fatal("versions should be equal")
+		}
+	}
+}
+
+func BenchmarkRBTreeSplit(b *testing.B) {
+
+	values := benchmarkValues()
+	rbTree := FromValues(comparator.OrderedComparator[int], values...)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rbTree.Split(values[i%benchmarkSize])
+	}
+}
+
+func BenchmarkRBTreeEqual(b *testing.B) {
+
+	values := benchmarkValues()
+	rbTree := FromValues(comparator.OrderedComparator[int], values...)
+	otherTree := New(comparator.OrderedComparator[int])
+	for _, value := range values {
+		otherTree, _ = otherTree.Insert(value)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if !rbTree.Equal(otherTree) {
+			b.Fatal("trees should be equal")
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package immutable_rb_tree
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+type (
+	// `Change` describes how a value differs between two versions of a tree:
+	// `Old` is absent for an added value, `New` is absent for a removed one, and both are present for a changed one.
+	Change[Value any] struct {
+		Old maybe.Maybe[Value]
+		New maybe.Maybe[Value]
+	}
+)
+
+// `Diff(oldTree, newTree, eq)` returns an iterator of changes from `oldTree` to `newTree` in ascending order.
+// Equal values of both trees are reported as changed unless `eq` holds for them, a nil `eq` never reports them.
+// Subtrees shared by both trees are skipped as a whole,
+// so diffing a tree against one derived from it costs time proportional to the changes rather than to the sizes.
+// CAUTION: Only invoke `Diff` with trees sharing the same comparator.
+func Diff[Value any](oldTree *RBTree[Value], newTree *RBTree[Value], eq func(old Value, new Value) bool) iter.Seq[Change[Value]] {
+	return func(yield func(Change[Value]) bool) {
+		diff(oldTree.cmp, oldTree.root, newTree.root, newTree.root.blackHeight(), eq, yield)
+	}
+}
+
+// Returning false means that `yield` stopped the iteration.
+func diff[Value any](cmp comparator.Comparator[Value], o *node[Value, noSummary], n *node[Value, noSummary], nbh int, eq func(Value, Value) bool, yield func(Change[Value]) bool) bool {
+
+	if o == n {
+		return true
+	}
+
+	if o == nil {
+		return n.rangeTraversal(cmp, [directionNum]maybe.Maybe[Bound[Value]]{}, directionLeft, func(added *node[Value, noSummary]) bool {
+			return yield(Change[Value]{New: maybe.Just(added.value)})
+		})
+	}
+
+	if n == nil {
+		return o.rangeTraversal(cmp, [directionNum]maybe.Maybe[Bound[Value]]{}, directionLeft, func(removed *node[Value, noSummary]) bool {
+			return yield(Change[Value]{Old: maybe.Just(removed.value)})
+		})
+	}
+
+	// NOTE: `split()` keeps the subtrees hanging off its search path intact, so shared subtrees remain recognizable.
+	less, lessBH, found, greater, greaterBH := n.split(cmp, o.value, nbh, nil)
+	if !diff(cmp, o.children[directionLeft], less, lessBH, eq, yield) {
+		return false
+	}
+
+	switch {
+	case found == nil:
+		if !yield(Change[Value]{Old: maybe.Just(o.value)}) {
+			return false
+		}
+	case eq != nil && !eq(o.value, *found):
+		if !yield(Change[Value]{Old: maybe.Just(o.value), New: maybe.Just(*found)}) {
+			return false
+		}
+	}
+
+	return diff(cmp, o.children[directionRight], greater, greaterBH, eq, yield)
+}
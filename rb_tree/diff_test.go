@@ -0,0 +1,77 @@
+package immutable_rb_tree
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+func TestDiff(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"diff(xs, ys) should report values only in xs as removed, values only in ys as added": func(xs []int8, ys []int8) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+			oldTree, newTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			added, removed := []int8(nil), []int8(nil)
+			for change := range Diff(oldTree, newTree, nil) {
+				switch {
+				case change.Old.IsNothing() && change.New.IsJust():
+					added = append(added, change.New.Value())
+				case change.Old.IsJust() && change.New.IsNothing():
+					removed = append(removed, change.Old.Value())
+				default:
+					return false
+				}
+			}
+
+			return slices.Equal(added, newTree.Difference(oldTree).Values()) &&
+				slices.Equal(removed, oldTree.Difference(newTree).Values())
+		},
+		"diff(xs, ys, eq) should report equal values for which eq fails as changed": func(xs []int8, ys []int8) bool {
+
+			cmp := func(l int8, r int8) int { return int(l/4) - int(r/4) }
+			oldTree, newTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			changed := []Change[int8](nil)
+			for change := range Diff(oldTree, newTree, func(l int8, r int8) bool { return l == r }) {
+				if change.Old.IsJust() && change.New.IsJust() {
+					changed = append(changed, change)
+				}
+			}
+
+			expected := []Change[int8](nil)
+			for old := range oldTree.All() {
+				if value := newTree.Lookup(old); value != nil && *value != old {
+					expected = append(expected, Change[int8]{Old: maybe.Just(old), New: maybe.Just(*value)})
+				}
+			}
+
+			return slices.EqualFunc(changed, expected, func(l Change[int8], r Change[int8]) bool {
+				return l.Old.Value() == r.Old.Value() && l.New.Value() == r.New.Value()
+			})
+		},
+		"diff should skip subtrees shared by both trees": func(x int) bool {
+
+			comparisons := 0
+			cmp := func(l int, r int) int {
+				comparisons++
+				return comparator.OrderedComparator[int](l, r)
+			}
+
+			oldTree := FromSortedSeq(cmp, func(yield func(int) bool) {
+				for i := 0; i < 1<<16 && yield(i*2); i++ {
+				}
+			})
+			x |= 1
+			newTree, _ := oldTree.Insert(x)
+
+			comparisons = 0
+			changes := slices.Collect(Diff(oldTree, newTree, nil))
+			return len(changes) == 1 && changes[0].New.Value() == x && comparisons < 1<<10
+		},
+	})
+}
@@ -0,0 +1,101 @@
+package immutable_rb_tree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// `Dump(w)` writes `rbTree` to `w` as indented text, one node per line in preorder with its color and count,
+// left children before right ones, and a missing child shown as `nil` when its sibling exists.
+func (rbTree *RBTree[Value]) Dump(w io.Writer) error {
+
+	sb := strings.Builder{}
+	if rbTree.root == nil {
+		sb.WriteString("nil\n")
+	} else {
+		rbTree.root.dump(&sb, "", "")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// `DumpDOT(w)` writes `rbTree` to `w` in the DOT language of Graphviz, e.g. to be rendered by `dot -Tsvg`.
+func (rbTree *RBTree[Value]) DumpDOT(w io.Writer) error {
+
+	sb := strings.Builder{}
+	sb.WriteString("digraph {\n")
+	sb.WriteString("\tnode [shape=circle, style=filled, fontcolor=white];\n")
+	rbTree.root.dumpDOT(&sb, "n")
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// `prefix` is written before the line of `n` itself, `childPrefix` before the lines of its descendants.
+func (n *node[Value, Summary]) dump(sb *strings.Builder, prefix string, childPrefix string) {
+
+	sb.WriteString(prefix)
+	if n == nil {
+		sb.WriteString("nil\n")
+		return
+	}
+	fmt.Fprintf(sb, "%v (%v, %d)\n", n.value, n.color, n.cnt)
+
+	if n.children[directionLeft] == nil && n.children[directionRight] == nil {
+		return
+	}
+	n.children[directionLeft].dump(sb, childPrefix+"├── ", childPrefix+"│   ")
+	n.children[directionRight].dump(sb, childPrefix+"└── ", childPrefix+"    ")
+}
+
+// `id` identifies `n` by its path from the root, where `l` and `r` stand for the directions taken.
+func (n *node[Value, Summary]) dumpDOT(sb *strings.Builder, id string) {
+
+	if n == nil {
+		fmt.Fprintf(sb, "\t%s [shape=point, label=\"\"];\n", id)
+		return
+	}
+
+	fmt.Fprintf(sb, "\t%s [label=%q, fillcolor=%s];\n", id, fmt.Sprint(n.value), n.color.fillColor())
+
+	for dir, suffix := range [directionNum]string{
+		directionLeft:  "l",
+		directionRight: "r",
+	} {
+		childID := id + suffix
+		fmt.Fprintf(sb, "\t%s -> %s;\n", id, childID)
+		n.children[dir].dumpDOT(sb, childID)
+	}
+}
+
+func (c color) String() string {
+	switch c {
+	case colorNegativeBlack:
+		return "negative-black"
+	case colorRed:
+		return "red"
+	case colorBlack:
+		return "black"
+	case colorDoubleBlack:
+		return "double-black"
+	default:
+		return fmt.Sprintf("color(%d)", int8(c))
+	}
+}
+
+// `fillColor()` returns the name of the color filling nodes of `c` in DOT.
+func (c color) fillColor() string {
+	switch c {
+	case colorNegativeBlack:
+		return "blue"
+	case colorRed:
+		return "red"
+	case colorBlack:
+		return "black"
+	default:
+		return "gray"
+	}
+}
@@ -0,0 +1,37 @@
+package immutable_rb_tree_test
+
+import (
+	"os"
+
+	"github.com/freebirdljj/immutable/comparator"
+	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
+)
+
+func ExampleRBTree_Dump() {
+	rbTree := immutable_rb_tree.FromValues(comparator.OrderedComparator[int], 1, 2, 3, 4)
+	rbTree.Dump(os.Stdout)
+	// Output:
+	// 3 (black, 4)
+	// ├── 2 (black, 2)
+	// │   ├── 1 (red, 1)
+	// │   └── nil
+	// └── 4 (black, 1)
+}
+
+func ExampleRBTree_DumpDOT() {
+	rbTree := immutable_rb_tree.FromValues(comparator.OrderedComparator[int], 1, 2)
+	rbTree.DumpDOT(os.Stdout)
+	// Output:
+	// digraph {
+	// 	node [shape=circle, style=filled, fontcolor=white];
+	// 	n [label="2", fillcolor=black];
+	// 	n -> nl;
+	// 	nl [label="1", fillcolor=red];
+	// 	nl -> nll;
+	// 	nll [shape=point, label=""];
+	// 	nl -> nlr;
+	// 	nlr [shape=point, label=""];
+	// 	n -> nr;
+	// 	nr [shape=point, label=""];
+	// }
+}
@@ -0,0 +1,456 @@
+package immutable_rb_tree
+
+import (
+	"math/bits"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+// References:
+// - Join-based algorithms: https://arxiv.org/abs/1602.02120
+
+// `Split(value)` returns a tree of values less than `value`, the value equal to `value` (if any), and a tree of values greater than `value`.
+func (rbTree *RBTree[Value]) Split(value Value) (less *RBTree[Value], found maybe.Maybe[Value], greater *RBTree[Value]) {
+	lessRoot, _, foundValue, greaterRoot, _ := rbTree.root.split(rbTree.cmp, value, rbTree.root.blackHeight(), nil)
+	return rbTree.withRoot(lessRoot.makeBlack(nil)), maybe.FromGoPointer(foundValue), rbTree.withRoot(greaterRoot.makeBlack(nil))
+}
+
+// CAUTION: Only invoke `Join` with trees sharing the same comparator, where all values of `left` are less than those of `right`.
+func Join[Value any](left *RBTree[Value], right *RBTree[Value]) *RBTree[Value] {
+
+	if right.Empty() {
+		return left
+	}
+
+	if left.Empty() {
+		return right
+	}
+
+	root, _ := join2(left.root, left.root.blackHeight(), right.root, right.root.blackHeight(), nil)
+	return left.withRoot(root.makeBlack(nil))
+}
+
+// `Union(other)` returns a tree of values in either `rbTree` or `other`, values of `rbTree` are preferred when equal.
+// CAUTION: Only invoke `Union` with trees sharing the same comparator, likewise for the other set operations.
+func (rbTree *RBTree[Value]) Union(other *RBTree[Value]) *RBTree[Value] {
+	root, _ := union(rbTree.cmp, rbTree.root, rbTree.root.blackHeight(), other.root, other.root.blackHeight(), nil, nil)
+	return rbTree.withRoot(root.makeBlack(nil))
+}
+
+// `UnionWith(other, resolve)` is like `Union(other)`, but equal values are resolved by `resolve`,
+// the value returned by `resolve` must be equal to the given ones.
+func (rbTree *RBTree[Value]) UnionWith(other *RBTree[Value], resolve func(left Value, right Value) Value) *RBTree[Value] {
+	root, _ := union(rbTree.cmp, rbTree.root, rbTree.root.blackHeight(), other.root, other.root.blackHeight(), resolve, nil)
+	return rbTree.withRoot(root.makeBlack(nil))
+}
+
+// `Intersection(other)` returns a tree of values in both `rbTree` and `other`, values of `rbTree` are preferred.
+func (rbTree *RBTree[Value]) Intersection(other *RBTree[Value]) *RBTree[Value] {
+	root, _ := intersection(rbTree.cmp, rbTree.root, rbTree.root.blackHeight(), other.root, other.root.blackHeight(), nil, nil)
+	return rbTree.withRoot(root.makeBlack(nil))
+}
+
+// `IntersectionWith(other, resolve)` is like `Intersection(other)`, but equal values are resolved by `resolve`,
+// the value returned by `resolve` must be equal to the given ones.
+func (rbTree *RBTree[Value]) IntersectionWith(other *RBTree[Value], resolve func(left Value, right Value) Value) *RBTree[Value] {
+	root, _ := intersection(rbTree.cmp, rbTree.root, rbTree.root.blackHeight(), other.root, other.root.blackHeight(), resolve, nil)
+	return rbTree.withRoot(root.makeBlack(nil))
+}
+
+// `Difference(other)` returns a tree of values in `rbTree` but not in `other`.
+func (rbTree *RBTree[Value]) Difference(other *RBTree[Value]) *RBTree[Value] {
+	root, _ := difference(rbTree.cmp, rbTree.root, rbTree.root.blackHeight(), other.root, nil)
+	return rbTree.withRoot(root.makeBlack(nil))
+}
+
+// `DifferenceWith(other, resolve)` returns a tree of values in `rbTree` but not in `other`,
+// together with values in both for which `resolve` returns a `Just` value, which must be equal to the given ones.
+func (rbTree *RBTree[Value]) DifferenceWith(other *RBTree[Value], resolve func(left Value, right Value) maybe.Maybe[Value]) *RBTree[Value] {
+	root, _ := differenceWith(rbTree.cmp, rbTree.root, rbTree.root.blackHeight(), other.root, other.root.blackHeight(), resolve, nil)
+	return rbTree.withRoot(root.makeBlack(nil))
+}
+
+// `SymmetricDifference(other)` returns a tree of values in exactly one of `rbTree` and `other`.
+func (rbTree *RBTree[Value]) SymmetricDifference(other *RBTree[Value]) *RBTree[Value] {
+	return rbTree.Difference(other).Union(other.Difference(rbTree))
+}
+
+// `IsSubsetOf(other)` reports whether all values of `rbTree` are in `other` in O(m + n),
+// skipping subtrees shared by both as a whole.
+func (rbTree *RBTree[Value]) IsSubsetOf(other *RBTree[Value]) bool {
+	return rbTree.cnt <= other.cnt && isSubset(rbTree.cmp, rbTree.root, other.root)
+}
+
+// `IsDisjoint(other)` reports whether no value of `rbTree` is in `other` in O(m + n),
+// which stops at the first subtree shared by both.
+func (rbTree *RBTree[Value]) IsDisjoint(other *RBTree[Value]) bool {
+	return isDisjoint(rbTree.cmp, rbTree.root, other.root)
+}
+
+// `Equal(other)` reports whether `rbTree` and `other` have equal values in O(m + n),
+// skipping subtrees shared by both as a whole, e.g. it costs O(1) for versions sharing the root.
+func (rbTree *RBTree[Value]) Equal(other *RBTree[Value]) bool {
+	return rbTree.cnt == other.cnt && equal(rbTree.cmp, rbTree.root, other.root)
+}
+
+func (rbTree *RBTree[Value]) withRoot(root *node[Value, noSummary]) *RBTree[Value] {
+	rbTreeCopy := *rbTree
+	rbTreeCopy.cnt = root.count()
+	rbTreeCopy.root = root
+	return &rbTreeCopy
+}
+
+// `blackHeight()` returns the number of black nodes on any path from `n` down to a leaf, including `n` itself.
+// NOTE: It costs O(log n), so it is only invoked once per operation, below which black heights are passed along with subtrees.
+func (n *node[Value, Summary]) blackHeight() int {
+	bh := 0
+	for ; n != nil; n = n.children[directionLeft] {
+		if n.color == colorBlack {
+			bh++
+		}
+	}
+	return bh
+}
+
+// `childBlackHeight()` returns the black height of children of `n`, given `bh` of `n` itself.
+func (n *node[Value, Summary]) childBlackHeight(bh int) int {
+	if n.color == colorBlack {
+		return bh - 1
+	}
+	return bh
+}
+
+// `blacken()` makes the root of `n` black, and returns it together with its black height, given `bh` before.
+func (n *node[Value, Summary]) blacken(bh int, e *editor[Value, Summary]) (*node[Value, Summary], int) {
+	if n.getColor() == colorRed {
+		return n.makeBlack(e), bh + 1
+	}
+	return n, bh
+}
+
+// `join()` returns a tree of all values of `l`, `value` and all values of `r`, whose root is black, together with its black height.
+// All values of `l` must be less than `value`, which must be less than all values of `r`;
+// `lbh` and `rbh` are black heights of `l` and `r`, so that `join()` costs O(|lbh - rbh| + 1).
+func join[Value any, Summary any](l *node[Value, Summary], lbh int, value Value, r *node[Value, Summary], rbh int, e *editor[Value, Summary]) (*node[Value, Summary], int) {
+
+	l, lbh = l.blacken(lbh, e)
+	r, rbh = r.blacken(rbh, e)
+
+	switch {
+	case lbh > rbh:
+		return l.joinSpine(directionRight, lbh, value, r, rbh, e).blacken(lbh, e)
+	case lbh < rbh:
+		return r.joinSpine(directionLeft, rbh, value, l, lbh, e).blacken(rbh, e)
+	default:
+		return newNode(
+			[directionNum]*node[Value, Summary]{
+				directionLeft:  l,
+				directionRight: r,
+			},
+			colorBlack,
+			value,
+			e,
+		), lbh + 1
+	}
+}
+
+// `joinSpine()` walks down the `dir` spine of `n` (whose black height is `bh`) until reaching a black node as high as `other`,
+// and replaces that node with a red one holding `value`, which is rebalanced on the way back just like `ins()`.
+// The black height of the result is still `bh`, though its root may turn red.
+func (n *node[Value, Summary]) joinSpine(dir direction, bh int, value Value, other *node[Value, Summary], otherBH int, e *editor[Value, Summary]) *node[Value, Summary] {
+
+	oppositeDir := directionLeft + directionRight - dir
+
+	if n.getColor() == colorBlack && bh == otherBH {
+		children := [directionNum]*node[Value, Summary]{}
+		children[dir] = other
+		children[oppositeDir] = n
+		return newNode(children, colorRed, value, e)
+	}
+
+	newChildren := n.children
+	newChildren[dir] = n.children[dir].joinSpine(dir, n.childBlackHeight(bh), value, other, otherBH, e)
+	return n.withChildren(newChildren, e).balance(e)
+}
+
+// The roots of `less` and `greater` returned by `split()` may be red,
+// `bh` is the black height of `n`, likewise `lessBH` and `greaterBH` are those of `less` and `greater`.
+func (n *node[Value, Summary]) split(cmp comparator.Comparator[Value], value Value, bh int, e *editor[Value, Summary]) (less *node[Value, Summary], lessBH int, found *Value, greater *node[Value, Summary], greaterBH int) {
+
+	if n == nil {
+		return nil, 0, nil, nil, 0
+	}
+
+	childBH := n.childBlackHeight(bh)
+
+	switch sign(cmp(value, n.value)) {
+	case -1:
+		less, lessBH, found, greater, greaterBH := n.children[directionLeft].split(cmp, value, childBH, e)
+		greater, greaterBH = join(greater, greaterBH, n.value, n.children[directionRight], childBH, e)
+		return less, lessBH, found, greater, greaterBH
+	case 1:
+		less, lessBH, found, greater, greaterBH := n.children[directionRight].split(cmp, value, childBH, e)
+		less, lessBH = join(n.children[directionLeft], childBH, n.value, less, lessBH, e)
+		return less, lessBH, found, greater, greaterBH
+	default:
+		return n.children[directionLeft], childBH, &n.value, n.children[directionRight], childBH
+	}
+}
+
+// `splitLast()` returns a tree of all values of `n` but the maximum, together with its black height and the maximum.
+// Only `splitLast` non-leaf node.
+func (n *node[Value, Summary]) splitLast(bh int, e *editor[Value, Summary]) (rest *node[Value, Summary], restBH int, last Value) {
+
+	childBH := n.childBlackHeight(bh)
+
+	if n.children[directionRight] == nil {
+		return n.children[directionLeft], childBH, n.value
+	}
+
+	rest, restBH, last = n.children[directionRight].splitLast(childBH, e)
+	rest, restBH = join(n.children[directionLeft], childBH, n.value, rest, restBH, e)
+	return rest, restBH, last
+}
+
+// `join2()` is like `join()` but without a middle value.
+func join2[Value any, Summary any](l *node[Value, Summary], lbh int, r *node[Value, Summary], rbh int, e *editor[Value, Summary]) (*node[Value, Summary], int) {
+
+	if l == nil {
+		return r, rbh
+	}
+
+	if r == nil {
+		return l, lbh
+	}
+
+	rest, restBH, last := l.splitLast(lbh, e)
+	return join(rest, restBH, last, r, rbh, e)
+}
+
+// The roots of results returned by `union()`, `intersection()`, `difference()` and `differenceWith()` may be red,
+// each of them is returned together with its black height.
+// A nil `resolve` prefers values of `l`, which allows sharing identical subtrees as a whole.
+func union[Value any, Summary any](cmp comparator.Comparator[Value], l *node[Value, Summary], lbh int, r *node[Value, Summary], rbh int, resolve func(Value, Value) Value, e *editor[Value, Summary]) (*node[Value, Summary], int) {
+
+	if r == nil || (l == r && resolve == nil) {
+		return l, lbh
+	}
+
+	if l == nil {
+		return r, rbh
+	}
+
+	less, lessBH, found, greater, greaterBH := r.split(cmp, l.value, rbh, e)
+
+	value := l.value
+	if found != nil && resolve != nil {
+		value = resolve(l.value, *found)
+	}
+
+	childBH := l.childBlackHeight(lbh)
+	newLeft, newLeftBH := union(cmp, l.children[directionLeft], childBH, less, lessBH, resolve, e)
+	newRight, newRightBH := union(cmp, l.children[directionRight], childBH, greater, greaterBH, resolve, e)
+	return join(newLeft, newLeftBH, value, newRight, newRightBH, e)
+}
+
+func intersection[Value any, Summary any](cmp comparator.Comparator[Value], l *node[Value, Summary], lbh int, r *node[Value, Summary], rbh int, resolve func(Value, Value) Value, e *editor[Value, Summary]) (*node[Value, Summary], int) {
+
+	if l == nil || r == nil {
+		return nil, 0
+	}
+
+	if l == r && resolve == nil {
+		return l, lbh
+	}
+
+	less, lessBH, found, greater, greaterBH := r.split(cmp, l.value, rbh, e)
+	childBH := l.childBlackHeight(lbh)
+	newLeft, newLeftBH := intersection(cmp, l.children[directionLeft], childBH, less, lessBH, resolve, e)
+	newRight, newRightBH := intersection(cmp, l.children[directionRight], childBH, greater, greaterBH, resolve, e)
+
+	switch {
+	case found == nil:
+		return join2(newLeft, newLeftBH, newRight, newRightBH, e)
+	case resolve == nil:
+		return join(newLeft, newLeftBH, l.value, newRight, newRightBH, e)
+	default:
+		return join(newLeft, newLeftBH, resolve(l.value, *found), newRight, newRightBH, e)
+	}
+}
+
+func difference[Value any, Summary any](cmp comparator.Comparator[Value], l *node[Value, Summary], lbh int, r *node[Value, Summary], e *editor[Value, Summary]) (*node[Value, Summary], int) {
+
+	if l == nil || l == r {
+		return nil, 0
+	}
+
+	if r == nil {
+		return l, lbh
+	}
+
+	less, lessBH, _, greater, greaterBH := l.split(cmp, r.value, lbh, e)
+	newLeft, newLeftBH := difference(cmp, less, lessBH, r.children[directionLeft], e)
+	newRight, newRightBH := difference(cmp, greater, greaterBH, r.children[directionRight], e)
+	return join2(newLeft, newLeftBH, newRight, newRightBH, e)
+}
+
+func differenceWith[Value any, Summary any](cmp comparator.Comparator[Value], l *node[Value, Summary], lbh int, r *node[Value, Summary], rbh int, resolve func(Value, Value) maybe.Maybe[Value], e *editor[Value, Summary]) (*node[Value, Summary], int) {
+
+	if l == nil {
+		return nil, 0
+	}
+
+	if r == nil {
+		return l, lbh
+	}
+
+	less, lessBH, found, greater, greaterBH := r.split(cmp, l.value, rbh, e)
+	childBH := l.childBlackHeight(lbh)
+	newLeft, newLeftBH := differenceWith(cmp, l.children[directionLeft], childBH, less, lessBH, resolve, e)
+	newRight, newRightBH := differenceWith(cmp, l.children[directionRight], childBH, greater, greaterBH, resolve, e)
+
+	if found == nil {
+		return join(newLeft, newLeftBH, l.value, newRight, newRightBH, e)
+	}
+
+	resolved := resolve(l.value, *found)
+	if resolved.IsNothing() {
+		return join2(newLeft, newLeftBH, newRight, newRightBH, e)
+	}
+	return join(newLeft, newLeftBH, resolved.Value(), newRight, newRightBH, e)
+}
+
+type (
+	// `cursor` walks a tree in order, exposing the next unvisited subtree as a whole before descending into it,
+	// so that walking two trees side by side can skip subtrees shared by both at once.
+	cursor[Value any, Summary any] struct {
+		subtree *node[Value, Summary]   // the next unvisited subtree, whose values come before those of `stack`
+		stack   []*node[Value, Summary] // nodes whose own values and right subtrees are unvisited
+	}
+)
+
+func newCursor[Value any, Summary any](n *node[Value, Summary]) *cursor[Value, Summary] {
+	return &cursor[Value, Summary]{
+		subtree: n,
+		// NOTE: The height of a red-black tree never exceeds twice the black height, which is at most `bits.Len(cnt+1)`.
+		stack: make([]*node[Value, Summary], 0, 2*bits.Len(uint(n.count()+1))),
+	}
+}
+
+func (c *cursor[Value, Summary]) done() bool {
+	return c.subtree == nil && len(c.stack) == 0
+}
+
+// `descend()` splits the next unvisited subtree into its root and its left subtree.
+func (c *cursor[Value, Summary]) descend() {
+	c.stack = append(c.stack, c.subtree)
+	c.subtree = c.subtree.children[directionLeft]
+}
+
+// CAUTION: Only invoke `peek` with cursors aligned by `align()` and not done.
+func (c *cursor[Value, Summary]) peek() Value {
+	return c.stack[len(c.stack)-1].value
+}
+
+// CAUTION: Only invoke `next` with cursors aligned by `align()` and not done.
+func (c *cursor[Value, Summary]) next() {
+	n := c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+	c.subtree = n.children[directionRight]
+}
+
+// `align()` descends `l` and `r` until either both of them are about to visit the same subtree, which is reported,
+// or both of them are about to visit single values.
+// The larger subtree is descended first, so that a subtree shared at different depths is met as a whole.
+func align[Value any, Summary any](l *cursor[Value, Summary], r *cursor[Value, Summary]) (shared bool) {
+	for {
+		switch {
+		case l.subtree == nil && r.subtree == nil:
+			return false
+		case l.subtree == r.subtree:
+			return true
+		case l.subtree.count() >= r.subtree.count():
+			l.descend()
+		default:
+			r.descend()
+		}
+	}
+}
+
+// `equal()`, `isSubset()` and `isDisjoint()` merge `l` and `r` in order in O(m + n) without allocating nodes,
+// skipping subtrees shared by both as a whole.
+func equal[Value any, Summary any](cmp comparator.Comparator[Value], l *node[Value, Summary], r *node[Value, Summary]) bool {
+
+	lc, rc := newCursor(l), newCursor(r)
+	for {
+
+		if align(lc, rc) {
+			lc.subtree, rc.subtree = nil, nil
+			continue
+		}
+
+		if lc.done() || rc.done() {
+			return lc.done() && rc.done()
+		}
+
+		if cmp(lc.peek(), rc.peek()) != 0 {
+			return false
+		}
+		lc.next()
+		rc.next()
+	}
+}
+
+func isSubset[Value any, Summary any](cmp comparator.Comparator[Value], l *node[Value, Summary], r *node[Value, Summary]) bool {
+
+	lc, rc := newCursor(l), newCursor(r)
+	for {
+
+		if align(lc, rc) {
+			lc.subtree, rc.subtree = nil, nil
+			continue
+		}
+
+		switch {
+		case lc.done():
+			return true
+		case rc.done():
+			return false
+		}
+
+		switch sign(cmp(lc.peek(), rc.peek())) {
+		case -1:
+			return false
+		case 0:
+			lc.next()
+			rc.next()
+		default:
+			rc.next()
+		}
+	}
+}
+
+func isDisjoint[Value any, Summary any](cmp comparator.Comparator[Value], l *node[Value, Summary], r *node[Value, Summary]) bool {
+
+	lc, rc := newCursor(l), newCursor(r)
+	for {
+
+		if align(lc, rc) {
+			return false
+		}
+
+		if lc.done() || rc.done() {
+			return true
+		}
+
+		switch sign(cmp(lc.peek(), rc.peek())) {
+		case -1:
+			lc.next()
+		case 0:
+			return false
+		default:
+			rc.next()
+		}
+	}
+}
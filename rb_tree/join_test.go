@@ -0,0 +1,176 @@
+package immutable_rb_tree
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+func TestRBTreeSplit(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.split(x) should partition values around x": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			less, found, greater := rbTree.Split(x)
+			return slices.Equal(less.Values(), slices.Collect(rbTree.UpTo(Exclusive(x)))) &&
+				slices.Equal(greater.Values(), slices.Collect(rbTree.From(Exclusive(x)))) &&
+				found.IsJust() == slices.Contains(xs, x) &&
+				(found.IsNothing() || found.Value() == x) &&
+				isBalanced(less) &&
+				isBalanced(greater)
+		},
+	})
+}
+
+func TestJoin(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"join(split(x)) == rb_tree.delete(x)": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			newRBTree, _ := rbTree.Delete(x)
+
+			less, _, greater := rbTree.Split(x)
+			joined := Join(less, greater)
+			return slices.Equal(joined.Values(), newRBTree.Values()) &&
+				joined.Count() == newRBTree.Count() &&
+				isBalanced(joined)
+		},
+		"join(fromValues(ls), fromValues(rs)) == fromValues(ls ++ rs) if ls < rs": func(ls []uint8, rs []uint8) bool {
+
+			shiftedRs := make([]int, 0, len(rs))
+			for _, r := range rs {
+				shiftedRs = append(shiftedRs, int(r)+256)
+			}
+
+			lTree := FromValues(comparator.OrderedComparator[int], intsFromUint8s(ls)...)
+			rTree := FromValues(comparator.OrderedComparator[int], shiftedRs...)
+
+			joined := Join(lTree, rTree)
+			return slices.Equal(joined.Values(), append(lTree.Values(), rTree.Values()...)) &&
+				isBalanced(joined)
+		},
+	})
+}
+
+func TestBlackHeight(t *testing.T) {
+
+	cmp := comparator.OrderedComparator[int8]
+
+	quick.CheckProperties(t, map[string]any{
+		"split(x) should return black heights of both sides": func(xs []int8, x int8) bool {
+			rbTree := FromValues(cmp, xs...)
+			less, lessBH, _, greater, greaterBH := rbTree.root.split(cmp, x, rbTree.root.blackHeight(), nil)
+			return lessBH == less.blackHeight() && greaterBH == greater.blackHeight()
+		},
+		"set operations should return black heights of their results": func(xs []int8, ys []int8) bool {
+
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			xBH, yBH := xTree.root.blackHeight(), yTree.root.blackHeight()
+
+			union, unionBH := union(cmp, xTree.root, xBH, yTree.root, yBH, nil, nil)
+			intersection, intersectionBH := intersection(cmp, xTree.root, xBH, yTree.root, yBH, nil, nil)
+			difference, differenceBH := difference(cmp, xTree.root, xBH, yTree.root, nil)
+			return unionBH == union.blackHeight() &&
+				intersectionBH == intersection.blackHeight() &&
+				differenceBH == difference.blackHeight()
+		},
+	})
+}
+
+func intsFromUint8s(xs []uint8) []int {
+	res := make([]int, 0, len(xs))
+	for _, x := range xs {
+		res = append(res, int(x))
+	}
+	return res
+}
+
+// `isBalanced()` reports whether `rbTree` satisfies all invariants checked by `Validate()`.
+func isBalanced[Value any](rbTree *RBTree[Value]) bool {
+	return rbTree.Validate() == nil
+}
+
+func TestRBTreeSetAlgebra(t *testing.T) {
+
+	cmp := comparator.OrderedComparator[int8]
+
+	// brute-force reference implementation
+	filter := func(xs *RBTree[int8], predicate func(int8) bool) []int8 {
+		res := []int8(nil)
+		for x := range xs.All() {
+			if predicate(x) {
+				res = append(res, x)
+			}
+		}
+		return res
+	}
+
+	quick.CheckProperties(t, map[string]any{
+		"union(xs, ys) == sort(uniq(xs ++ ys))": func(xs []int8, ys []int8) bool {
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			union := xTree.Union(yTree)
+			return slices.Equal(union.Values(), FromValues(cmp, append(xs, ys...)...).Values()) && isBalanced(union)
+		},
+		"intersection(xs, ys) == filter(in ys, xs)": func(xs []int8, ys []int8) bool {
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			intersection := xTree.Intersection(yTree)
+			return slices.Equal(intersection.Values(), filter(xTree, func(x int8) bool { return yTree.Lookup(x) != nil })) && isBalanced(intersection)
+		},
+		"difference(xs, ys) == filter(not in ys, xs)": func(xs []int8, ys []int8) bool {
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			difference := xTree.Difference(yTree)
+			return slices.Equal(difference.Values(), filter(xTree, func(x int8) bool { return yTree.Lookup(x) == nil })) && isBalanced(difference)
+		},
+		"symmetricDifference(xs, ys) == union(xs, ys) - intersection(xs, ys)": func(xs []int8, ys []int8) bool {
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			symmetricDifference := xTree.SymmetricDifference(yTree)
+			return slices.Equal(symmetricDifference.Values(), xTree.Union(yTree).Difference(xTree.Intersection(yTree)).Values()) && isBalanced(symmetricDifference)
+		},
+		"union(xs, xs) === intersection(xs, xs) === xs": func(xs []int8) bool {
+			xTree := FromValues(cmp, xs...)
+			return xTree.Union(xTree).root == xTree.root && xTree.Intersection(xTree).root == xTree.root && xTree.Difference(xTree).Empty()
+		},
+		"xs.isSubsetOf(ys) == all(in ys, xs)": func(xs []int8, ys []int8) bool {
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			return xTree.IsSubsetOf(yTree) == (len(filter(xTree, func(x int8) bool { return yTree.Lookup(x) == nil })) == 0) &&
+				xTree.Intersection(yTree).IsSubsetOf(yTree)
+		},
+		"xs.isDisjoint(ys) == none(in ys, xs)": func(xs []int8, ys []int8) bool {
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			return xTree.IsDisjoint(yTree) == (len(filter(xTree, func(x int8) bool { return yTree.Lookup(x) != nil })) == 0) &&
+				xTree.Difference(yTree).IsDisjoint(yTree)
+		},
+		"xs.equal(ys) == (xs.values() == ys.values())": func(xs []int8, ys []int8) bool {
+			xTree, yTree := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			return xTree.Equal(yTree) == slices.Equal(xTree.Values(), yTree.Values())
+		},
+		"versions sharing subtrees should be compared as a whole": func(xs []int8, x int8) bool {
+
+			xTree := FromValues(cmp, xs...)
+			newTree, _ := xTree.Insert(x)
+			newTree, _ = newTree.Delete(x)
+			if xTree.Lookup(x) != nil {
+				newTree, _ = newTree.Insert(x)
+			}
+
+			return xTree.Equal(newTree) && newTree.Equal(xTree) && xTree.IsSubsetOf(newTree) &&
+				(xTree.Empty() || !xTree.IsDisjoint(newTree))
+		},
+		"union(xs, ys) should prefer values of xs": func(xs []int8, ys []int8) bool {
+
+			const N = 10
+			modCmp := comparator.CascadeComparator(comparator.OrderedComparator[int8], func(x int8) int8 { return x % N })
+
+			xTree, yTree := FromValues(modCmp, xs...), FromValues(modCmp, ys...)
+			for value := range xTree.Union(yTree).All() {
+				if x := xTree.Lookup(value); x != nil && *x != value {
+					return false
+				}
+			}
+			return true
+		},
+	})
+}
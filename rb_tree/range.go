@@ -0,0 +1,119 @@
+package immutable_rb_tree
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+type (
+	// `Bound` is one end of a range, `Inclusive` indicates whether `Value` itself falls into the range.
+	Bound[Value any] struct {
+		Value     Value
+		Inclusive bool
+	}
+)
+
+func Inclusive[Value any](value Value) Bound[Value] {
+	return Bound[Value]{
+		Value:     value,
+		Inclusive: true,
+	}
+}
+
+func Exclusive[Value any](value Value) Bound[Value] {
+	return Bound[Value]{
+		Value:     value,
+		Inclusive: false,
+	}
+}
+
+// `Range(lo, hi)` returns an iterator of all values between `lo` and `hi` in ascending order.
+func (rbTree *RBTree[Value]) Range(lo Bound[Value], hi Bound[Value]) iter.Seq[Value] {
+	return rbTree.rangeTraversal(maybe.Just(lo), maybe.Just(hi), directionLeft)
+}
+
+// `From(lo)` returns an iterator of all values above `lo` in ascending order.
+func (rbTree *RBTree[Value]) From(lo Bound[Value]) iter.Seq[Value] {
+	return rbTree.rangeTraversal(maybe.Just(lo), maybe.Nothing[Bound[Value]](), directionLeft)
+}
+
+// `UpTo(hi)` returns an iterator of all values below `hi` in ascending order.
+func (rbTree *RBTree[Value]) UpTo(hi Bound[Value]) iter.Seq[Value] {
+	return rbTree.rangeTraversal(maybe.Nothing[Bound[Value]](), maybe.Just(hi), directionLeft)
+}
+
+// `Backward()` returns an iterator of all values in descending order.
+func (rbTree *RBTree[Value]) Backward() iter.Seq[Value] {
+	return rbTree.rangeTraversal(maybe.Nothing[Bound[Value]](), maybe.Nothing[Bound[Value]](), directionRight)
+}
+
+// `BackwardRange(lo, hi)` returns an iterator of all values between `lo` and `hi` in descending order.
+func (rbTree *RBTree[Value]) BackwardRange(lo Bound[Value], hi Bound[Value]) iter.Seq[Value] {
+	return rbTree.rangeTraversal(maybe.Just(lo), maybe.Just(hi), directionRight)
+}
+
+// `BackwardFrom(lo)` returns an iterator of all values above `lo` in descending order.
+func (rbTree *RBTree[Value]) BackwardFrom(lo Bound[Value]) iter.Seq[Value] {
+	return rbTree.rangeTraversal(maybe.Just(lo), maybe.Nothing[Bound[Value]](), directionRight)
+}
+
+// `BackwardUpTo(hi)` returns an iterator of all values below `hi` in descending order.
+func (rbTree *RBTree[Value]) BackwardUpTo(hi Bound[Value]) iter.Seq[Value] {
+	return rbTree.rangeTraversal(maybe.Nothing[Bound[Value]](), maybe.Just(hi), directionRight)
+}
+
+// `first` is the direction to visit first, `directionLeft` for ascending order and `directionRight` for descending order.
+func (rbTree *RBTree[Value]) rangeTraversal(lo maybe.Maybe[Bound[Value]], hi maybe.Maybe[Bound[Value]], first direction) iter.Seq[Value] {
+	return func(yield func(Value) bool) {
+		bounds := [directionNum]maybe.Maybe[Bound[Value]]{
+			directionLeft:  lo,
+			directionRight: hi,
+		}
+		rbTree.root.rangeTraversal(rbTree.cmp, bounds, first, func(n *node[Value, noSummary]) bool {
+			return yield(n.value)
+		})
+	}
+}
+
+// `rangeTraversal()` skips every subtree lying entirely outside of the range,
+// so it only costs O(log n) to reach the first value in the range.
+// Returning false means that either `yield` stopped the iteration or the last bound has been exceeded.
+func (n *node[Value, Summary]) rangeTraversal(cmp comparator.Comparator[Value], bounds [directionNum]maybe.Maybe[Bound[Value]], first direction, yield func(*node[Value, Summary]) bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	last := directionLeft + directionRight - first
+
+	if !admits(cmp, bounds[first], first, n.value) {
+		return n.children[last].rangeTraversal(cmp, bounds, first, yield)
+	}
+
+	if !n.children[first].rangeTraversal(cmp, bounds, first, yield) {
+		return false
+	}
+
+	if !admits(cmp, bounds[last], last, n.value) {
+		return false
+	}
+
+	return yield(n) && n.children[last].rangeTraversal(cmp, bounds, first, yield)
+}
+
+// `admits()` reports whether `value` satisfies `bound`, which is a lower bound on the left side or an upper bound on the right side.
+// An absent bound admits any value.
+func admits[Value any](cmp comparator.Comparator[Value], bound maybe.Maybe[Bound[Value]], side direction, value Value) bool {
+
+	if bound.IsNothing() {
+		return true
+	}
+
+	c := sign(cmp(value, bound.Value().Value))
+	if side == directionRight {
+		c = -c
+	}
+	return c > 0 || (c == 0 && bound.Value().Inclusive)
+}
@@ -0,0 +1,141 @@
+package immutable_rb_tree
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+func TestRBTreeRange(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.range(lo, hi) == filter(in range, rb_tree.values())": func(xs []int8, lo int8, loInclusive bool, hi int8, hiInclusive bool) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int8], xs...)
+
+			expected := []int8(nil)
+			for _, value := range rbTree.Values() {
+				if (lo < value || loInclusive && lo == value) && (value < hi || hiInclusive && value == hi) {
+					expected = append(expected, value)
+				}
+			}
+
+			return slices.Equal(
+				slices.Collect(rbTree.Range(Bound[int8]{Value: lo, Inclusive: loInclusive}, Bound[int8]{Value: hi, Inclusive: hiInclusive})),
+				expected,
+			)
+		},
+		"rb_tree.range(inclusive(x), inclusive(x)) == [x] if rb_tree.lookup(x) != nil": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], append(xs, x)...)
+
+			return slices.Equal(
+				slices.Collect(rbTree.Range(Inclusive(x), Inclusive(x))),
+				[]int{x},
+			)
+		},
+		"rb_tree.range(exclusive(x), exclusive(x)) == []": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], append(xs, x)...)
+
+			return slices.Collect(rbTree.Range(Exclusive(x), Exclusive(x))) == nil
+		},
+	})
+}
+
+func TestRBTreeFrom(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.from(inclusive(x)) == drop(rank(x), rb_tree.values())": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			return slices.Equal(
+				slices.Collect(rbTree.From(Inclusive(x))),
+				rbTree.Values()[rbTree.Rank(x):],
+			)
+		},
+		"rb_tree.from(lo)(Konst(false)) should only iterate over at most 1 value": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], append(xs, x)...)
+
+			cnt := 0
+			rbTree.From(Inclusive(x))(func(value int) bool {
+				cnt++
+				return false
+			})
+
+			return cnt == 1
+		},
+	})
+}
+
+func TestRBTreeUpTo(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.upTo(exclusive(x)) == take(rank(x), rb_tree.values())": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			return slices.Equal(
+				slices.Collect(rbTree.UpTo(Exclusive(x))),
+				rbTree.Values()[:rbTree.Rank(x)],
+			)
+		},
+	})
+}
+
+func TestRBTreeBackward(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.backward() == reverse(rb_tree.values())": func(xs []int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			values := rbTree.Values()
+			slices.Reverse(values)
+			return slices.Equal(slices.Collect(rbTree.Backward()), values)
+		},
+		"rb_tree.backward()(Konst(false)) should only iterate over at most 1 value": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], append(xs, x)...)
+
+			cnt := 0
+			rbTree.Backward()(func(value int) bool {
+				cnt++
+				return false
+			})
+
+			return cnt == 1
+		},
+	})
+}
+
+func TestRBTreeBackwardRange(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.backwardRange(lo, hi) == reverse(rb_tree.range(lo, hi))": func(xs []int8, lo int8, loInclusive bool, hi int8, hiInclusive bool) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int8], xs...)
+			loBound := Bound[int8]{Value: lo, Inclusive: loInclusive}
+			hiBound := Bound[int8]{Value: hi, Inclusive: hiInclusive}
+
+			values := slices.Collect(rbTree.Range(loBound, hiBound))
+			slices.Reverse(values)
+			return slices.Equal(slices.Collect(rbTree.BackwardRange(loBound, hiBound)), values)
+		},
+		"rb_tree.backwardFrom(lo) == reverse(rb_tree.from(lo))": func(xs []int, lo int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			values := slices.Collect(rbTree.From(Exclusive(lo)))
+			slices.Reverse(values)
+			return slices.Equal(slices.Collect(rbTree.BackwardFrom(Exclusive(lo))), values)
+		},
+		"rb_tree.backwardUpTo(hi) == reverse(rb_tree.upTo(hi))": func(xs []int, hi int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			values := slices.Collect(rbTree.UpTo(Inclusive(hi)))
+			slices.Reverse(values)
+			return slices.Equal(slices.Collect(rbTree.BackwardUpTo(Inclusive(hi))), values)
+		},
+	})
+}
@@ -7,29 +7,46 @@ package immutable_rb_tree
 
 import (
 	"iter"
+	"math/bits"
 	"slices"
 
 	"github.com/freebirdljj/immutable/comparator"
-	immutable_iter "github.com/freebirdljj/immutable/iter"
+	"github.com/freebirdljj/immutable/maybe"
 )
 
 type (
 	// The zero value of `RBTree` makes nonsense.
 	RBTree[Value any] struct {
 		cmp             comparator.Comparator[Value]
-		doubleBlackLeaf *node[Value]
+		doubleBlackLeaf *node[Value, noSummary]
 		cnt             int
-		root            *node[Value]
+		root            *node[Value, noSummary]
 	}
 
-	node[Value any] struct {
-		children [directionNum]*node[Value]
+	// NOTE: `summary` is placed before `value` so that `noSummary` takes no space.
+	node[Value any, Summary any] struct {
+		children [directionNum]*node[Value, Summary]
+		cnt      int // number of values in the subtree rooted at this node
 		color    color
+		edit     *editToken // the `Transient` owning this node, nil if persistent
+		summary  Summary    // the summary of this subtree, computed whenever the node is created
 		value    Value
 	}
 
-	color     int8
-	direction int8
+	// `noSummary` is the summary of nodes of `RBTree`, which are not summarized at all.
+	noSummary struct{}
+
+	// `editor` creates nodes on behalf of an operation,
+	// nodes owned by `edit` are edited in place, and summaries of the nodes created are computed by `monoid`.
+	// A nil `editor` copies all nodes and computes no summary.
+	editor[Value any, Summary any] struct {
+		edit   *editToken
+		monoid *Monoid[Value, Summary]
+	}
+
+	color      int8
+	direction  int8
+	alteration int8
 )
 
 const (
@@ -45,21 +62,60 @@ const (
 	directionNum
 )
 
+const (
+	alterationNone alteration = iota
+	alterationReplacement
+	alterationInsertion
+	alterationDeletion
+)
+
 func New[Value any](cmp comparator.Comparator[Value]) *RBTree[Value] {
 	return &RBTree[Value]{
 		cmp: cmp,
-		doubleBlackLeaf: &node[Value]{
+		doubleBlackLeaf: &node[Value, noSummary]{
 			color: colorDoubleBlack,
 		},
 	}
 }
 
+// Among equal values, the last one is preserved.
 func FromValues[Value any](cmp comparator.Comparator[Value], values ...Value) *RBTree[Value] {
-	rbTree := New(cmp)
-	for _, value := range values {
-		rbTree, _ = rbTree.Insert(value)
+	return FromSeq(cmp, slices.Values(values))
+}
+
+// `FromSeq()` sorts all values of `seq` and then builds the tree in linear time.
+// Among equal values, the last one is preserved.
+func FromSeq[Value any](cmp comparator.Comparator[Value], seq iter.Seq[Value]) *RBTree[Value] {
+	values := slices.Collect(seq)
+	slices.SortStableFunc(values, cmp)
+	return FromSortedSeq(cmp, slices.Values(values))
+}
+
+// `FromSortedValues()` builds the tree in linear time.
+// Among equal values, the last one is preserved.
+// CAUTION: Only invoke `FromSortedValues` with `values` sorted in ascending order.
+func FromSortedValues[Value any](cmp comparator.Comparator[Value], values ...Value) *RBTree[Value] {
+	return FromSortedSeq(cmp, slices.Values(values))
+}
+
+// `FromSortedSeq()` builds the tree in linear time.
+// Among equal values, the last one is preserved.
+// CAUTION: Only invoke `FromSortedSeq` with finite `seq` sorted in ascending order.
+func FromSortedSeq[Value any](cmp comparator.Comparator[Value], seq iter.Seq[Value]) *RBTree[Value] {
+
+	values := []Value(nil)
+	for value := range seq {
+		if len(values) > 0 && cmp(values[len(values)-1], value) == 0 {
+			values[len(values)-1] = value
+		} else {
+			values = append(values, value)
+		}
 	}
-	return rbTree
+
+	rbTree := New(cmp)
+	// all levels are full except the deepest one, which is colored red.
+	redDepth := bits.Len(uint(len(values)+1)) - 1
+	return rbTree.withRoot(fromSortedValues(values, 0, redDepth))
 }
 
 func (rbTree *RBTree[Value]) Empty() bool {
@@ -74,6 +130,26 @@ func (rbTree *RBTree[Value]) Lookup(value Value) *Value {
 	return rbTree.root.lookup(rbTree.cmp, value)
 }
 
+// `Floor(value)` returns the greatest value less than or equal to `value`.
+func (rbTree *RBTree[Value]) Floor(value Value) maybe.Maybe[Value] {
+	return maybe.FromGoPointer(rbTree.root.closest(rbTree.cmp, Inclusive(value), directionRight))
+}
+
+// `Ceiling(value)` returns the least value greater than or equal to `value`.
+func (rbTree *RBTree[Value]) Ceiling(value Value) maybe.Maybe[Value] {
+	return maybe.FromGoPointer(rbTree.root.closest(rbTree.cmp, Inclusive(value), directionLeft))
+}
+
+// `Lower(value)` returns the greatest value strictly less than `value`.
+func (rbTree *RBTree[Value]) Lower(value Value) maybe.Maybe[Value] {
+	return maybe.FromGoPointer(rbTree.root.closest(rbTree.cmp, Exclusive(value), directionRight))
+}
+
+// `Higher(value)` returns the least value strictly greater than `value`.
+func (rbTree *RBTree[Value]) Higher(value Value) maybe.Maybe[Value] {
+	return maybe.FromGoPointer(rbTree.root.closest(rbTree.cmp, Exclusive(value), directionLeft))
+}
+
 // CAUTION: Only invoke `Maximum` with non-empty RBTree.
 func (rbTree *RBTree[Value]) Maximum() Value {
 	node := rbTree.root
@@ -98,29 +174,94 @@ func (rbTree *RBTree[Value]) Minimum() Value {
 	}
 }
 
+func (rbTree *RBTree[Value]) Max() maybe.Maybe[Value] {
+	return maybe.FromGoPointer(rbTree.root.extreme(directionRight))
+}
+
+func (rbTree *RBTree[Value]) Min() maybe.Maybe[Value] {
+	return maybe.FromGoPointer(rbTree.root.extreme(directionLeft))
+}
+
+// `PopMax()` removes the maximum, `newTree` is the original one if the RBTree is empty.
+func (rbTree *RBTree[Value]) PopMax() (newTree *RBTree[Value], max maybe.Maybe[Value]) {
+	return rbTree.popExtreme(directionRight)
+}
+
+// `PopMin()` removes the minimum, `newTree` is the original one if the RBTree is empty.
+func (rbTree *RBTree[Value]) PopMin() (newTree *RBTree[Value], min maybe.Maybe[Value]) {
+	return rbTree.popExtreme(directionLeft)
+}
+
+// `At(k)` returns the k-th (0-indexed) smallest value, or nil if `k` is out of range.
+func (rbTree *RBTree[Value]) At(k int) *Value {
+
+	if k < 0 || k >= rbTree.cnt {
+		return nil
+	}
+
+	node := rbTree.root
+	for {
+		leftCnt := node.children[directionLeft].count()
+		switch {
+		case k < leftCnt:
+			node = node.children[directionLeft]
+		case k > leftCnt:
+			k -= leftCnt + 1
+			node = node.children[directionRight]
+		default:
+			return &node.value
+		}
+	}
+}
+
+// `Rank(value)` returns the number of values which are less than `value`.
+func (rbTree *RBTree[Value]) Rank(value Value) int {
+	rank := 0
+	for node := rbTree.root; node != nil; {
+		if rbTree.cmp(value, node.value) <= 0 {
+			node = node.children[directionLeft]
+		} else {
+			rank += node.children[directionLeft].count() + 1
+			node = node.children[directionRight]
+		}
+	}
+	return rank
+}
+
+// `CountRange(lo, hi)` returns the number of values in the half-open range [lo, hi).
+func (rbTree *RBTree[Value]) CountRange(lo Value, hi Value) int {
+	if rbTree.cmp(lo, hi) >= 0 {
+		return 0
+	}
+	return rbTree.Rank(hi) - rbTree.Rank(lo)
+}
+
 // `All()` Returns an iterator of all values in an in-order traversal.
 func (rbTree *RBTree[Value]) All() iter.Seq[Value] {
 	return rbTree.InorderTraversal()
 }
 
 func (rbTree *RBTree[Value]) Values() []Value {
-	values := []Value(nil)
-	rbTree.InorderTraversal()(
-		func(value Value) bool {
-			values = append(values, value)
-			return true
-		},
-	)
+
+	if rbTree.Empty() {
+		return nil
+	}
+
+	values := make([]Value, 0, rbTree.Count())
+	for n := range rbTree.root.inorderTraversal() {
+		values = append(values, n.value)
+	}
 	return values
 }
 
 func (rbTree *RBTree[Value]) InorderTraversal() iter.Seq[Value] {
-	return immutable_iter.Map(
-		rbTree.root.inorderTraversal(),
-		func(n *node[Value]) Value {
-			return n.value
-		},
-	)
+	return func(yield func(Value) bool) {
+		for n := range rbTree.root.inorderTraversal() {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
 }
 
 // `newTree` returned by `Insert()` is always different from the original one.
@@ -129,7 +270,7 @@ func (rbTree *RBTree[Value]) Insert(value Value) (newTree *RBTree[Value], affect
 
 	rbTreeCopy := *rbTree
 
-	newRoot, affected := rbTreeCopy.root.insert(rbTree.cmp, value)
+	newRoot, affected := rbTreeCopy.root.insert(rbTree.cmp, value, nil)
 	if affected {
 		rbTreeCopy.cnt++
 	}
@@ -142,7 +283,7 @@ func (rbTree *RBTree[Value]) Insert(value Value) (newTree *RBTree[Value], affect
 // otherwise nothing happens, `newTree` is the original one.
 func (rbTree *RBTree[Value]) Delete(value Value) (newTree *RBTree[Value], affected bool) {
 
-	newRoot, affected := rbTree.root.delete(rbTree.cmp, value, rbTree.doubleBlackLeaf)
+	newRoot, affected := rbTree.root.delete(rbTree.cmp, value, rbTree.doubleBlackLeaf, nil)
 	if !affected {
 		return rbTree, false
 	}
@@ -153,55 +294,188 @@ func (rbTree *RBTree[Value]) Delete(value Value) (newTree *RBTree[Value], affect
 	return &rbTreeCopy, true
 }
 
-func (n *node[Value]) getColor() color {
+// `Alter(value, f)` looks up `value` and passes the equal value found (if any) to `f` in a single traversal,
+// the equal value is then replaced with, or a new value is inserted as, the `Just` value returned by `f`;
+// the equal value is deleted, or nothing happens, if `f` returns `Nothing`.
+// `old` is the equal value before alteration; `newTree` is the original one if nothing happens.
+// CAUTION: The `Just` value returned by `f` must be equal to `value`.
+func (rbTree *RBTree[Value]) Alter(value Value, f func(old maybe.Maybe[Value]) maybe.Maybe[Value]) (newTree *RBTree[Value], old maybe.Maybe[Value]) {
+
+	newRoot, oldValue, change := rbTree.root.alter(rbTree.cmp, value, f, rbTree.doubleBlackLeaf, nil)
+	if change == alterationNone {
+		return rbTree, maybe.FromGoPointer(oldValue)
+	}
+
+	rbTreeCopy := *rbTree
+	switch change {
+	case alterationInsertion:
+		rbTreeCopy.cnt++
+	case alterationDeletion:
+		rbTreeCopy.cnt--
+	}
+
+	rbTreeCopy.root = newRoot
+	return &rbTreeCopy, maybe.FromGoPointer(oldValue)
+}
+
+func (rbTree *RBTree[Value]) popExtreme(dir direction) (newTree *RBTree[Value], extreme maybe.Maybe[Value]) {
+
+	if rbTree.root == nil {
+		return rbTree, maybe.Nothing[Value]()
+	}
+
+	newRoot, value := rbTree.root.popExtreme(dir, rbTree.doubleBlackLeaf, nil)
+
+	rbTreeCopy := *rbTree
+	rbTreeCopy.cnt--
+	rbTreeCopy.root = newRoot
+	return &rbTreeCopy, maybe.Just(value)
+}
+
+func fromSortedValues[Value any](values []Value, depth int, redDepth int) *node[Value, noSummary] {
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	color := colorBlack
+	if depth == redDepth {
+		color = colorRed
+	}
+
+	mid := len(values) / 2
+	return newNode(
+		[directionNum]*node[Value, noSummary]{
+			directionLeft:  fromSortedValues(values[:mid], depth+1, redDepth),
+			directionRight: fromSortedValues(values[mid+1:], depth+1, redDepth),
+		},
+		color,
+		values[mid],
+		nil,
+	)
+}
+
+func newNode[Value any, Summary any](children [directionNum]*node[Value, Summary], color color, value Value, e *editor[Value, Summary]) *node[Value, Summary] {
+	n := &node[Value, Summary]{
+		children: children,
+		cnt:      children[directionLeft].count() + 1 + children[directionRight].count(),
+		color:    color,
+		edit:     e.editToken(),
+		value:    value,
+	}
+	e.summarize(n)
+	return n
+}
+
+// The (double black) leaf counts as empty.
+func (n *node[Value, Summary]) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.cnt
+}
+
+// `editable()` returns `n` itself if it is owned by `e`, otherwise a copy of `n` owned by `e`.
+func (n *node[Value, Summary]) editable(e *editor[Value, Summary]) *node[Value, Summary] {
+
+	edit := e.editToken()
+	if edit != nil && n.edit == edit {
+		return n
+	}
+
+	nCopy := *n
+	nCopy.edit = edit
+	return &nCopy
+}
+
+func (n *node[Value, Summary]) getColor() color {
 	if n == nil {
 		return colorBlack
 	}
 	return n.color
 }
 
-func (n *node[Value]) makeRed() *node[Value] {
-	nCopy := *n
+func (n *node[Value, Summary]) makeRed(e *editor[Value, Summary]) *node[Value, Summary] {
+	nCopy := n.editable(e)
 	nCopy.color = colorRed
-	return &nCopy
+	return nCopy
 }
 
-func (n *node[Value]) makeBlack() *node[Value] {
+func (n *node[Value, Summary]) makeBlack(e *editor[Value, Summary]) *node[Value, Summary] {
 
-	if n == nil {
-		return nil
+	if n == nil || n.color == colorBlack {
+		return n
 	}
 
-	nCopy := *n
+	nCopy := n.editable(e)
 	nCopy.color = colorBlack
-	return &nCopy
+	return nCopy
 }
 
 // Only used by `bubble()`.
-func (n *node[Value]) makeRedder(doubleBlackLeaf *node[Value]) *node[Value] {
+func (n *node[Value, Summary]) makeRedder(doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) *node[Value, Summary] {
 
 	if n == doubleBlackLeaf {
 		return nil
 	}
 
-	nCopy := *n
+	nCopy := n.editable(e)
 	nCopy.color = redder(n.color)
-	return &nCopy
+	return nCopy
 }
 
-func (n *node[Value]) withChildren(children [directionNum]*node[Value]) *node[Value] {
-	nCopy := *n
+func (n *node[Value, Summary]) withChildren(children [directionNum]*node[Value, Summary], e *editor[Value, Summary]) *node[Value, Summary] {
+	nCopy := n.editable(e)
 	nCopy.children = children
-	return &nCopy
+	nCopy.cnt = children[directionLeft].count() + 1 + children[directionRight].count()
+	e.summarize(nCopy)
+	return nCopy
 }
 
-func (n *node[Value]) withEqualValue(value Value) *node[Value] {
-	nCopy := *n
+func (n *node[Value, Summary]) withEqualValue(value Value, e *editor[Value, Summary]) *node[Value, Summary] {
+	nCopy := n.editable(e)
 	nCopy.value = value
-	return &nCopy
+	e.summarize(nCopy)
+	return nCopy
+}
+
+// The nil `editor` owns no node.
+func (e *editor[Value, Summary]) editToken() *editToken {
+	if e == nil {
+		return nil
+	}
+	return e.edit
+}
+
+// `summarize()` computes the summary of `n` from those of its children,
+// which is only invoked on nodes being created, so summaries of published nodes never change.
+// NOTE: Changing the color never affects the summary, so only new values and new children need it.
+func (e *editor[Value, Summary]) summarize(n *node[Value, Summary]) {
+
+	if e == nil || e.monoid == nil {
+		return
+	}
+
+	n.summary = e.monoid.Combine(
+		e.monoid.Combine(e.monoid.summaryOf(n.children[directionLeft]), e.monoid.Measure(n.value)),
+		e.monoid.summaryOf(n.children[directionRight]),
+	)
+}
+
+// `extreme(directionLeft)` returns the minimum, `extreme(directionRight)` returns the maximum.
+func (n *node[Value, Summary]) extreme(dir direction) *Value {
+
+	if n == nil {
+		return nil
+	}
+
+	for n.children[dir] != nil {
+		n = n.children[dir]
+	}
+	return &n.value
 }
 
-func (n *node[Value]) lookup(cmp comparator.Comparator[Value], value Value) *Value {
+func (n *node[Value, Summary]) lookup(cmp comparator.Comparator[Value], value Value) *Value {
 
 	if n == nil {
 		return nil
@@ -217,19 +491,47 @@ func (n *node[Value]) lookup(cmp comparator.Comparator[Value], value Value) *Val
 	}
 }
 
-func (n *node[Value]) inorderTraversal() iter.Seq[*node[Value]] {
-	if n == nil {
-		return immutable_iter.Empty[*node[Value]]()
+// `closest()` returns the value closest to `bound.Value` among those satisfying `bound`,
+// which is a lower bound on the left side or an upper bound on the right side.
+func (n *node[Value, Summary]) closest(cmp comparator.Comparator[Value], bound Bound[Value], side direction) *Value {
+	res := (*Value)(nil)
+	oppositeSide := directionLeft + directionRight - side
+	for n != nil {
+		if admits(cmp, maybe.Just(bound), side, n.value) {
+			res = &n.value
+			n = n.children[side]
+		} else {
+			n = n.children[oppositeSide]
+		}
+	}
+	return res
+}
+
+// `inorderTraversal()` keeps the left spine of unvisited nodes on an explicit stack, which never grows beyond the height of the tree.
+func (n *node[Value, Summary]) inorderTraversal() iter.Seq[*node[Value, Summary]] {
+	return func(yield func(*node[Value, Summary]) bool) {
+
+		// NOTE: The height of a red-black tree never exceeds twice the black height, which is at most `bits.Len(cnt+1)`.
+		stack := make([]*node[Value, Summary], 0, 2*bits.Len(uint(n.count()+1)))
+
+		for p := n; p != nil || len(stack) > 0; p = p.children[directionRight] {
+
+			for ; p != nil; p = p.children[directionLeft] {
+				stack = append(stack, p)
+			}
+
+			p = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if !yield(p) {
+				return
+			}
+		}
 	}
-	return immutable_iter.Concat(slices.Values([]iter.Seq[*node[Value]]{
-		n.children[directionLeft].inorderTraversal(),
-		immutable_iter.Singleton(n),
-		n.children[directionRight].inorderTraversal(),
-	}))
 }
 
 // Only `balance` non-leaf node.
-func (n *node[Value]) balance() *node[Value] {
+func (n *node[Value, Summary]) balance(e *editor[Value, Summary]) *node[Value, Summary] {
 
 	// try to find a red child with a red grandchild.
 	if n.color == colorBlack || n.color == colorDoubleBlack {
@@ -248,42 +550,45 @@ func (n *node[Value]) balance() *node[Value] {
 
 			switch {
 			case child.children[dir].getColor() == colorRed:
-				grandchildren := [directionNum]*node[Value]{}
+				grandchildren := [directionNum]*node[Value, Summary]{}
 				grandchildren[dir] = child.children[oppositeDir]
 				grandchildren[oppositeDir] = n.children[oppositeDir]
 
-				newChildren := [directionNum]*node[Value]{}
-				newChildren[dir] = child.children[dir].makeBlack()
-				newChildren[oppositeDir] = n.withChildren(grandchildren).makeBlack()
+				newChildren := [directionNum]*node[Value, Summary]{}
+				newChildren[dir] = child.children[dir].makeBlack(e)
+				newChildren[oppositeDir] = n.withChildren(grandchildren, e).makeBlack(e)
 
-				return &node[Value]{
-					children: newChildren,
-					color:    color,
-					value:    child.value,
-				}
+				return newNode(
+					newChildren,
+					color,
+					child.value,
+					e,
+				)
 			case child.children[oppositeDir].getColor() == colorRed:
-				newChildren := [directionNum]*node[Value]{}
+				newChildren := [directionNum]*node[Value, Summary]{}
 				{
-					grandchildren := [directionNum]*node[Value]{}
+					grandchildren := [directionNum]*node[Value, Summary]{}
 					grandchildren[dir] = child.children[dir]
 					grandchildren[oppositeDir] = child.children[oppositeDir].children[dir]
-					newChildren[dir] = &node[Value]{
-						children: grandchildren,
-						color:    colorBlack,
-						value:    child.value,
-					}
+					newChildren[dir] = newNode(
+						grandchildren,
+						colorBlack,
+						child.value,
+						e,
+					)
 				}
 				{
-					grandchildren := [directionNum]*node[Value]{}
+					grandchildren := [directionNum]*node[Value, Summary]{}
 					grandchildren[dir] = child.children[oppositeDir].children[oppositeDir]
 					grandchildren[oppositeDir] = n.children[oppositeDir]
-					newChildren[oppositeDir] = n.withChildren(grandchildren).makeBlack()
-				}
-				return &node[Value]{
-					children: newChildren,
-					color:    color,
-					value:    child.children[oppositeDir].value,
+					newChildren[oppositeDir] = n.withChildren(grandchildren, e).makeBlack(e)
 				}
+				return newNode(
+					newChildren,
+					color,
+					child.children[oppositeDir].value,
+					e,
+				)
 			}
 		}
 	}
@@ -301,33 +606,36 @@ func (n *node[Value]) balance() *node[Value] {
 
 			oppositeDir := directionLeft + directionRight - dir
 
-			newChildren := [directionNum]*node[Value]{}
+			newChildren := [directionNum]*node[Value, Summary]{}
 			{
-				grandchildren := [directionNum]*node[Value]{}
-				grandchildren[dir] = child.children[dir].makeRed()
+				grandchildren := [directionNum]*node[Value, Summary]{}
+				grandchildren[dir] = child.children[dir].makeRed(e)
 				grandchildren[oppositeDir] = child.children[oppositeDir].children[dir]
-				newChildren[dir] = (&node[Value]{
-					children: grandchildren,
-					color:    colorBlack,
-					value:    child.value,
-				}).balance()
+				newChildren[dir] = newNode(
+					grandchildren,
+					colorBlack,
+					child.value,
+					e,
+				).balance(e)
 			}
 			{
-				grandchildren := [directionNum]*node[Value]{}
+				grandchildren := [directionNum]*node[Value, Summary]{}
 				grandchildren[dir] = child.children[oppositeDir].children[oppositeDir]
 				grandchildren[oppositeDir] = n.children[oppositeDir]
-				newChildren[oppositeDir] = &node[Value]{
-					children: grandchildren,
-					color:    colorBlack,
-					value:    n.value,
-				}
+				newChildren[oppositeDir] = newNode(
+					grandchildren,
+					colorBlack,
+					n.value,
+					e,
+				)
 			}
 
-			return &node[Value]{
-				children: newChildren,
-				color:    colorBlack,
-				value:    child.children[oppositeDir].value,
-			}
+			return newNode(
+				newChildren,
+				colorBlack,
+				child.children[oppositeDir].value,
+				e,
+			)
 		}
 	}
 
@@ -336,62 +644,65 @@ func (n *node[Value]) balance() *node[Value] {
 
 // `newNode` returned by `ins()` is always different from the original one.
 // `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
-func (n *node[Value]) ins(cmp comparator.Comparator[Value], value Value) (*node[Value], bool) {
+func (n *node[Value, Summary]) ins(cmp comparator.Comparator[Value], value Value, e *editor[Value, Summary]) (*node[Value, Summary], bool) {
 
 	if n == nil {
-		return &node[Value]{
-			value: value,
-			color: colorRed,
-		}, true
+		return newNode(
+			[directionNum]*node[Value, Summary]{},
+			colorRed,
+			value,
+			e,
+		), true
 	}
 
 	switch sign(cmp(value, n.value)) {
 	case -1:
-		newLeftChild, affected := n.children[directionLeft].ins(cmp, value)
-		return n.withChildren([directionNum]*node[Value]{
+		newLeftChild, affected := n.children[directionLeft].ins(cmp, value, e)
+		return n.withChildren([directionNum]*node[Value, Summary]{
 			directionLeft:  newLeftChild,
 			directionRight: n.children[directionRight],
-		}).balance(), affected
+		}, e).balance(e), affected
 	case 1:
-		newRightChild, affected := n.children[directionRight].ins(cmp, value)
-		return n.withChildren([directionNum]*node[Value]{
+		newRightChild, affected := n.children[directionRight].ins(cmp, value, e)
+		return n.withChildren([directionNum]*node[Value, Summary]{
 			directionLeft:  n.children[directionLeft],
 			directionRight: newRightChild,
-		}).balance(), affected
+		}, e).balance(e), affected
 	default:
-		return n.withEqualValue(value), false
+		return n.withEqualValue(value, e), false
 	}
 }
 
 // `newNode` returned by `insert()` is always different from the original one.
 // `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
-func (n *node[Value]) insert(cmp comparator.Comparator[Value], value Value) (newNode *node[Value], affected bool) {
-	result, affected := n.ins(cmp, value)
-	return result.makeBlack(), affected
+func (n *node[Value, Summary]) insert(cmp comparator.Comparator[Value], value Value, e *editor[Value, Summary]) (newNode *node[Value, Summary], affected bool) {
+	result, affected := n.ins(cmp, value, e)
+	return result.makeBlack(e), affected
 }
 
 // Only `bubble` non-leaf node.
-func (n *node[Value]) bubble(doubleBlackLeaf *node[Value]) *node[Value] {
+func (n *node[Value, Summary]) bubble(doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) *node[Value, Summary] {
 	for _, child := range n.children {
 		if child.getColor() == colorDoubleBlack {
-			n = &node[Value]{
-				children: [directionNum]*node[Value]{
-					directionLeft:  n.children[directionLeft].makeRedder(doubleBlackLeaf),
-					directionRight: n.children[directionRight].makeRedder(doubleBlackLeaf),
+			n = newNode(
+				[directionNum]*node[Value, Summary]{
+					directionLeft:  n.children[directionLeft].makeRedder(doubleBlackLeaf, e),
+					directionRight: n.children[directionRight].makeRedder(doubleBlackLeaf, e),
 				},
-				color: blacker(n.color),
-				value: n.value,
-			}
+				blacker(n.color),
+				n.value,
+				e,
+			)
 			break
 		}
 	}
-	return n.balance()
+	return n.balance(e)
 }
 
-func (n *node[Value]) remove(doubleBlackLeaf *node[Value]) *node[Value] {
+func (n *node[Value, Summary]) remove(doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) *node[Value, Summary] {
 
 	// all children are leaves
-	if n.children == [directionNum]*node[Value]{nil, nil} {
+	if n.children == [directionNum]*node[Value, Summary]{nil, nil} {
 		switch n.color {
 		case colorRed:
 			return nil
@@ -408,38 +719,49 @@ func (n *node[Value]) remove(doubleBlackLeaf *node[Value]) *node[Value] {
 		if n.children[dir] == nil {
 			oppositeDir := directionLeft + directionRight - dir
 			nonLeafChild := n.children[oppositeDir]
-			return nonLeafChild.makeBlack()
+			return nonLeafChild.makeBlack(e)
 		}
 	}
 
-	newLeftChild, maxInLeft := n.children[directionLeft].removeMax(doubleBlackLeaf)
-	return (&node[Value]{
-		children: [directionNum]*node[Value]{
+	newLeftChild, maxInLeft := n.children[directionLeft].removeExtreme(directionRight, doubleBlackLeaf, e)
+	return newNode(
+		[directionNum]*node[Value, Summary]{
 			directionLeft:  newLeftChild,
 			directionRight: n.children[directionRight],
 		},
-		color: n.color,
-		value: maxInLeft,
-	}).bubble(doubleBlackLeaf)
+		n.color,
+		maxInLeft,
+		e,
+	).bubble(doubleBlackLeaf, e)
 }
 
-func (n *node[Value]) removeMax(doubleBlackLeaf *node[Value]) (newNode *node[Value], max Value) {
+// `removeExtreme(directionLeft)` removes the minimum, `removeExtreme(directionRight)` removes the maximum.
+func (n *node[Value, Summary]) removeExtreme(dir direction, doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) (newNode *node[Value, Summary], extreme Value) {
 
-	rightChild := n.children[directionRight]
-	if rightChild == nil {
-		return n.remove(doubleBlackLeaf), n.value
+	child := n.children[dir]
+	if child == nil {
+		return n.remove(doubleBlackLeaf, e), n.value
+	}
+
+	newChildren := n.children
+	newChildren[dir], extreme = child.removeExtreme(dir, doubleBlackLeaf, e)
+	return n.withChildren(newChildren, e).bubble(doubleBlackLeaf, e), extreme
+}
+
+// Only `popExtreme` non-leaf node.
+func (n *node[Value, Summary]) popExtreme(dir direction, doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) (newNode *node[Value, Summary], extreme Value) {
+
+	result, extreme := n.removeExtreme(dir, doubleBlackLeaf, e)
+	if result == doubleBlackLeaf {
+		return nil, extreme
 	}
 
-	newRightChild, max := rightChild.removeMax(doubleBlackLeaf)
-	return n.withChildren([directionNum]*node[Value]{
-		directionLeft:  n.children[directionLeft],
-		directionRight: newRightChild,
-	}).bubble(doubleBlackLeaf), max
+	return result.makeBlack(e), extreme
 }
 
 // `affected` is true, meaning that a real deletion occurred, `newNode` will be different from the original;
 // otherwise nothing happens, `newNode` is the original one.
-func (n *node[Value]) del(cmp comparator.Comparator[Value], value Value, doubleBlackLeaf *node[Value]) (newNode *node[Value], affected bool) {
+func (n *node[Value, Summary]) del(cmp comparator.Comparator[Value], value Value, doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) (newNode *node[Value, Summary], affected bool) {
 
 	if n == nil {
 		return nil, false
@@ -448,36 +770,36 @@ func (n *node[Value]) del(cmp comparator.Comparator[Value], value Value, doubleB
 	switch sign(cmp(value, n.value)) {
 	case -1:
 
-		newLeftChild, affected := n.children[directionLeft].del(cmp, value, doubleBlackLeaf)
+		newLeftChild, affected := n.children[directionLeft].del(cmp, value, doubleBlackLeaf, e)
 		if !affected {
 			return n, false
 		}
 
-		return n.withChildren([directionNum]*node[Value]{
+		return n.withChildren([directionNum]*node[Value, Summary]{
 			directionLeft:  newLeftChild,
 			directionRight: n.children[directionRight],
-		}).bubble(doubleBlackLeaf), true
+		}, e).bubble(doubleBlackLeaf, e), true
 	case 1:
 
-		newRightChild, affected := n.children[directionRight].del(cmp, value, doubleBlackLeaf)
+		newRightChild, affected := n.children[directionRight].del(cmp, value, doubleBlackLeaf, e)
 		if !affected {
 			return n, false
 		}
 
-		return n.withChildren([directionNum]*node[Value]{
+		return n.withChildren([directionNum]*node[Value, Summary]{
 			directionLeft:  n.children[directionLeft],
 			directionRight: newRightChild,
-		}).bubble(doubleBlackLeaf), true
+		}, e).bubble(doubleBlackLeaf, e), true
 	default:
-		return n.remove(doubleBlackLeaf), true
+		return n.remove(doubleBlackLeaf, e), true
 	}
 }
 
 // `affected` is true, meaning that a real deletion occurred, `newNode` will be different from the original;
 // otherwise nothing happens, `newNode` is the original one.
-func (n *node[Value]) delete(cmp comparator.Comparator[Value], value Value, doubleBlackLeaf *node[Value]) (newNode *node[Value], affected bool) {
+func (n *node[Value, Summary]) delete(cmp comparator.Comparator[Value], value Value, doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) (newNode *node[Value, Summary], affected bool) {
 
-	result, affected := n.del(cmp, value, doubleBlackLeaf)
+	result, affected := n.del(cmp, value, doubleBlackLeaf, e)
 	if !affected {
 		return n, false
 	}
@@ -486,23 +808,81 @@ func (n *node[Value]) delete(cmp comparator.Comparator[Value], value Value, doub
 		return nil, true
 	}
 
-	return result.makeBlack(), true
+	return result.makeBlack(e), true
+}
+
+// `change` indicates how the subtree was altered, `result` is the original one if nothing happens.
+func (n *node[Value, Summary]) alt(cmp comparator.Comparator[Value], value Value, f func(maybe.Maybe[Value]) maybe.Maybe[Value], doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) (result *node[Value, Summary], old *Value, change alteration) {
+
+	if n == nil {
+		res := f(maybe.Nothing[Value]())
+		if res.IsNothing() {
+			return nil, nil, alterationNone
+		}
+		return newNode(
+			[directionNum]*node[Value, Summary]{},
+			colorRed,
+			res.Value(),
+			e,
+		), nil, alterationInsertion
+	}
+
+	dir := directionLeft
+	switch sign(cmp(value, n.value)) {
+	case 0:
+		// `n` might be edited in place, so keep a copy of the old value.
+		old := n.value
+		res := f(maybe.Just(old))
+		if res.IsNothing() {
+			return n.remove(doubleBlackLeaf, e), &old, alterationDeletion
+		}
+		return n.withEqualValue(res.Value(), e), &old, alterationReplacement
+	case 1:
+		dir = directionRight
+	}
+
+	newChild, old, change := n.children[dir].alt(cmp, value, f, doubleBlackLeaf, e)
+	if change == alterationNone {
+		return n, old, change
+	}
+
+	newChildren := n.children
+	newChildren[dir] = newChild
+	result = n.withChildren(newChildren, e)
+
+	switch change {
+	case alterationInsertion:
+		result = result.balance(e)
+	case alterationDeletion:
+		result = result.bubble(doubleBlackLeaf, e)
+	}
+
+	return result, old, change
+}
+
+// `newNode` returned by `alter()` is the original one if nothing happens.
+func (n *node[Value, Summary]) alter(cmp comparator.Comparator[Value], value Value, f func(maybe.Maybe[Value]) maybe.Maybe[Value], doubleBlackLeaf *node[Value, Summary], e *editor[Value, Summary]) (newNode *node[Value, Summary], old *Value, change alteration) {
+
+	result, old, change := n.alt(cmp, value, f, doubleBlackLeaf, e)
+	switch {
+	case change == alterationNone:
+		return n, old, change
+	case result == doubleBlackLeaf:
+		return nil, old, change
+	default:
+		return result.makeBlack(e), old, change
+	}
 }
 
+// Colors are declared in ascending order of blackness, so `blacker()` and `redder()` are simply one step along it.
+// CAUTION: Only invoke `blacker` with colors redder than double-black.
 func blacker(c color) color {
-	return map[color]color{
-		colorNegativeBlack: colorRed,
-		colorRed:           colorBlack,
-		colorBlack:         colorDoubleBlack,
-	}[c]
+	return c + 1
 }
 
+// CAUTION: Only invoke `redder` with colors blacker than negative-black.
 func redder(c color) color {
-	return map[color]color{
-		colorRed:         colorNegativeBlack,
-		colorBlack:       colorRed,
-		colorDoubleBlack: colorBlack,
-	}[c]
+	return c - 1
 }
 
 func sign(x int) int {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/freebirdljj/immutable/comparator"
 	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
 )
 
 func TestRBTreeInsert(t *testing.T) {
@@ -135,3 +136,272 @@ func TestRBTreeInorderTraversal(t *testing.T) {
 		},
 	})
 }
+
+func TestRBTreeAt(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.fromValues(xs).at(k) == sort(uniq(xs))[k]": func(xs []int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			values := rbTree.Values()
+
+			for k, value := range values {
+				if v := rbTree.At(k); v == nil || *v != value {
+					return false
+				}
+			}
+			return true
+		},
+		"rb_tree.at(k) == nil if k is out of range": func(xs []int, k int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			if 0 <= k && k < rbTree.Count() {
+				k = -1 - k
+			}
+			return rbTree.At(k) == nil
+		},
+	})
+}
+
+func TestRBTreeRank(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.fromValues(xs).rank(x) == len(filter(< x, uniq(xs)))": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			expected := 0
+			for _, value := range rbTree.Values() {
+				if value < x {
+					expected++
+				}
+			}
+			return rbTree.Rank(x) == expected
+		},
+		"rb_tree.fromValues(xs).rank(at(k)) == k": func(xs []int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			for k := range rbTree.Count() {
+				if rbTree.Rank(*rbTree.At(k)) != k {
+					return false
+				}
+			}
+			return true
+		},
+		"rank should be maintained after deletion": func(xs []int, x int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], append(xs, x)...)
+			newRBTree, _ := rbTree.Delete(x)
+
+			return newRBTree.Rank(x) == rbTree.Rank(x) &&
+				newRBTree.CountRange(x, x+1) == 0 &&
+				newRBTree.Count() == rbTree.Count()-1
+		},
+	})
+}
+
+func TestRBTreeCountRange(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.fromValues(xs).countRange(lo, hi) == len(filter(in [lo, hi), uniq(xs)))": func(xs []int, lo int, hi int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+
+			expected := 0
+			for _, value := range rbTree.Values() {
+				if lo <= value && value < hi {
+					expected++
+				}
+			}
+			return rbTree.CountRange(lo, hi) == expected
+		},
+	})
+}
+
+func TestRBTreeFloorAndCeiling(t *testing.T) {
+
+	// brute-force reference implementation over sorted `values`
+	closest := func(values []int, admits func(int) bool, last bool) maybe.Maybe[int] {
+		res := maybe.Nothing[int]()
+		for _, value := range values {
+			if admits(value) {
+				res = maybe.Just(value)
+				if !last {
+					break
+				}
+			}
+		}
+		return res
+	}
+
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.floor(x) == max(filter(<= x, xs))": func(xs []int, x int) bool {
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			return reflect.DeepEqual(rbTree.Floor(x), closest(rbTree.Values(), func(value int) bool { return value <= x }, true))
+		},
+		"rb_tree.lower(x) == max(filter(< x, xs))": func(xs []int, x int) bool {
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			return reflect.DeepEqual(rbTree.Lower(x), closest(rbTree.Values(), func(value int) bool { return value < x }, true))
+		},
+		"rb_tree.ceiling(x) == min(filter(>= x, xs))": func(xs []int, x int) bool {
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			return reflect.DeepEqual(rbTree.Ceiling(x), closest(rbTree.Values(), func(value int) bool { return value >= x }, false))
+		},
+		"rb_tree.higher(x) == min(filter(> x, xs))": func(xs []int, x int) bool {
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			return reflect.DeepEqual(rbTree.Higher(x), closest(rbTree.Values(), func(value int) bool { return value > x }, false))
+		},
+		"rb_tree.floor(x) == rb_tree.ceiling(x) == x if x in xs": func(xs []int, x int) bool {
+			rbTree := FromValues(comparator.OrderedComparator[int], append(xs, x)...)
+			return rbTree.Floor(x).OrValue(x+1) == x && rbTree.Ceiling(x).OrValue(x-1) == x
+		},
+	})
+}
+
+func TestRBTreeMaxAndMin(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"rb_tree.max() == just(rb_tree.maximum()) and rb_tree.min() == just(rb_tree.minimum()) if non-empty": func(xs []int, lastX int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], append(xs, lastX)...)
+
+			return reflect.DeepEqual(rbTree.Max(), maybe.Just(rbTree.Maximum())) &&
+				reflect.DeepEqual(rbTree.Min(), maybe.Just(rbTree.Minimum()))
+		},
+		"new(cmp).max() == new(cmp).min() == nothing()": func() bool {
+			rbTree := New(comparator.OrderedComparator[int])
+			return rbTree.Max().IsNothing() && rbTree.Min().IsNothing()
+		},
+	})
+}
+
+func TestRBTreePopMaxAndPopMin(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"popping max repeatedly yields values in descending order": func(xs []int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			values := rbTree.Values()
+
+			popped := []int(nil)
+			for max := rbTree.Max(); max.IsJust(); max = rbTree.Max() {
+				newRBTree, popMax := rbTree.PopMax()
+				if !reflect.DeepEqual(popMax, max) || newRBTree.Count() != rbTree.Count()-1 {
+					return false
+				}
+				popped = append(popped, popMax.Value())
+				rbTree = newRBTree
+			}
+
+			slices.Reverse(popped)
+			return rbTree.Empty() && slices.Equal(popped, values)
+		},
+		"popping min repeatedly yields values in ascending order": func(xs []int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			values := rbTree.Values()
+
+			popped := []int(nil)
+			for !rbTree.Empty() {
+				newRBTree, min := rbTree.PopMin()
+				if !slices.Equal(newRBTree.Values(), values[len(popped)+1:]) {
+					return false
+				}
+				popped = append(popped, min.Value())
+				rbTree = newRBTree
+			}
+
+			return slices.Equal(popped, values)
+		},
+		"popping from an empty RBTree makes nothing happen": func() bool {
+
+			rbTree := New(comparator.OrderedComparator[int])
+
+			newRBTree, max := rbTree.PopMax()
+			if newRBTree != rbTree || max.IsJust() {
+				return false
+			}
+
+			newRBTree, min := rbTree.PopMin()
+			return newRBTree == rbTree && min.IsNothing()
+		},
+	})
+}
+
+func TestRBTreeAlter(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"altering keeps the tree balanced": func(xs []int8, ys []int8) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int8], xs...)
+			expected := rbTree
+
+			for _, y := range ys {
+				toggle := func(old maybe.Maybe[int8]) maybe.Maybe[int8] {
+					if old.IsJust() {
+						return maybe.Nothing[int8]()
+					}
+					return maybe.Just(y)
+				}
+				rbTree, _ = rbTree.Alter(y, toggle)
+				if newExpected, affected := expected.Delete(y); affected {
+					expected = newExpected
+				} else {
+					expected, _ = expected.Insert(y)
+				}
+				if !isBalanced(rbTree) {
+					return false
+				}
+			}
+
+			return slices.Equal(rbTree.Values(), expected.Values())
+		},
+	})
+}
+
+func TestFromSortedValues(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromSortedValues(sort(xs)) == fromValues(xs)": func(xs []int) bool {
+
+			sorted := slices.Clone(xs)
+			slices.Sort(sorted)
+
+			rbTree := FromSortedValues(comparator.OrderedComparator[int], sorted...)
+			return isBalanced(rbTree) &&
+				slices.Equal(rbTree.Values(), slices.Compact(sorted)) &&
+				rbTree.Count() == len(slices.Compact(sorted))
+		},
+		"fromSortedValues(xs) should preserve the last one among equal values, as insertion does": func(xs []int) bool {
+
+			const N = 10
+			cmp := comparator.CascadeComparator(comparator.OrderedComparator[int], func(x int) int { return x / N })
+
+			sorted := slices.Clone(xs)
+			slices.SortStableFunc(sorted, cmp)
+
+			expected := New(cmp)
+			for _, value := range sorted {
+				expected, _ = expected.Insert(value)
+			}
+
+			rbTree := FromSortedValues(cmp, sorted...)
+			return isBalanced(rbTree) && slices.Equal(rbTree.Values(), expected.Values())
+		},
+	})
+}
+
+func TestFromSeq(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromSeq(xs) should be the same as inserting values one by one": func(xs []int) bool {
+
+			const N = 10
+			cmp := comparator.CascadeComparator(comparator.OrderedComparator[int], func(x int) int { return x / N })
+
+			expected := New(cmp)
+			for _, value := range xs {
+				expected, _ = expected.Insert(value)
+			}
+
+			rbTree := FromSeq(cmp, slices.Values(xs))
+			return isBalanced(rbTree) &&
+				slices.Equal(rbTree.Values(), expected.Values()) &&
+				rbTree.Count() == expected.Count()
+		},
+	})
+}
@@ -0,0 +1,226 @@
+package immutable_rb_tree
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+type (
+	// `Monoid` summarizes values: the summary of a sequence of values is the `Combine` of their `Measure`s,
+	// where `Combine` must be associative and `Empty` must be its identity,
+	// e.g. the sum of sizes, the maximum of end points, or a polynomial hash.
+	Monoid[Value any, Summary any] struct {
+		Empty   Summary
+		Combine func(l Summary, r Summary) Summary
+		Measure func(value Value) Summary
+	}
+
+	// `Summarized` is an `RBTree` whose nodes carry summaries of their subtrees by a `Monoid`,
+	// each computed once when the node is created at the cost of O(1),
+	// so that summaries of arbitrary ranges can be queried in O(log n).
+	// The zero value of `Summarized` makes nonsense.
+	Summarized[Value any, Summary any] struct {
+		cmp             comparator.Comparator[Value]
+		doubleBlackLeaf *node[Value, Summary]
+		cnt             int
+		root            *node[Value, Summary]
+		editor          *editor[Value, Summary] // never edits nodes in place, only computes summaries of new ones
+	}
+)
+
+func NewSummarized[Value any, Summary any](cmp comparator.Comparator[Value], monoid Monoid[Value, Summary]) *Summarized[Value, Summary] {
+	return &Summarized[Value, Summary]{
+		cmp: cmp,
+		doubleBlackLeaf: &node[Value, Summary]{
+			color:   colorDoubleBlack,
+			summary: monoid.Empty,
+		},
+		editor: &editor[Value, Summary]{
+			monoid: &monoid,
+		},
+	}
+}
+
+// `Summarize(rbTree, monoid)` returns a tree of all values of `rbTree` summarized by `monoid` in O(n),
+// which copies the shape of `rbTree` with all nodes created afresh.
+func Summarize[Value any, Summary any](rbTree *RBTree[Value], monoid Monoid[Value, Summary]) *Summarized[Value, Summary] {
+	summarized := NewSummarized(rbTree.cmp, monoid)
+	summarized.cnt = rbTree.cnt
+	summarized.root = withSummaries(rbTree.root, summarized.editor)
+	return summarized
+}
+
+func (summarized *Summarized[Value, Summary]) Empty() bool {
+	return summarized.root == nil
+}
+
+func (summarized *Summarized[Value, Summary]) Count() int {
+	return summarized.cnt
+}
+
+func (summarized *Summarized[Value, Summary]) Lookup(value Value) *Value {
+	return summarized.root.lookup(summarized.cmp, value)
+}
+
+// `All()` Returns an iterator of all values in an in-order traversal.
+func (summarized *Summarized[Value, Summary]) All() iter.Seq[Value] {
+	return func(yield func(Value) bool) {
+		for n := range summarized.root.inorderTraversal() {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// `Range(lo, hi)` returns an iterator of all values between `lo` and `hi` in ascending order.
+func (summarized *Summarized[Value, Summary]) Range(lo Bound[Value], hi Bound[Value]) iter.Seq[Value] {
+	return func(yield func(Value) bool) {
+		bounds := [directionNum]maybe.Maybe[Bound[Value]]{
+			directionLeft:  maybe.Just(lo),
+			directionRight: maybe.Just(hi),
+		}
+		summarized.root.rangeTraversal(summarized.cmp, bounds, directionLeft, func(n *node[Value, Summary]) bool {
+			return yield(n.value)
+		})
+	}
+}
+
+// `Summary()` returns the summary of all values in O(1).
+func (summarized *Summarized[Value, Summary]) Summary() Summary {
+	return summarized.editor.monoid.summaryOf(summarized.root)
+}
+
+// `Fold(lo, hi)` returns the summary of all values between `lo` and `hi` in O(log n),
+// combining summaries of the subtrees lying entirely in the range.
+func (summarized *Summarized[Value, Summary]) Fold(lo Bound[Value], hi Bound[Value]) Summary {
+	bounds := [directionNum]maybe.Maybe[Bound[Value]]{
+		directionLeft:  maybe.Just(lo),
+		directionRight: maybe.Just(hi),
+	}
+	return summarized.editor.monoid.fold(summarized.cmp, summarized.root, bounds)
+}
+
+// `Search(admit)` returns an iterator of values whose measures are admitted by `admit` in ascending order,
+// skipping every subtree whose summary is not admitted, which makes it output-sensitive.
+// `admit` must be monotonic: whenever it admits the measure of a value, it must also admit every summary combined from it,
+// e.g. "the maximum end point is after x", otherwise values in pruned subtrees are missed.
+func (summarized *Summarized[Value, Summary]) Search(admit func(Summary) bool) iter.Seq[Value] {
+	return func(yield func(Value) bool) {
+		summarized.editor.monoid.search(summarized.root, admit, yield)
+	}
+}
+
+// `newTree` returned by `Insert()` is always different from the original one.
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+func (summarized *Summarized[Value, Summary]) Insert(value Value) (newTree *Summarized[Value, Summary], affected bool) {
+
+	summarizedCopy := *summarized
+
+	newRoot, affected := summarizedCopy.root.insert(summarized.cmp, value, summarized.editor)
+	if affected {
+		summarizedCopy.cnt++
+	}
+
+	summarizedCopy.root = newRoot
+	return &summarizedCopy, affected
+}
+
+// `affected` is true, meaning that a real deletion occurred, `newTree` will be different from the original;
+// otherwise nothing happens, `newTree` is the original one.
+func (summarized *Summarized[Value, Summary]) Delete(value Value) (newTree *Summarized[Value, Summary], affected bool) {
+
+	newRoot, affected := summarized.root.delete(summarized.cmp, value, summarized.doubleBlackLeaf, summarized.editor)
+	if !affected {
+		return summarized, false
+	}
+
+	summarizedCopy := *summarized
+	summarizedCopy.cnt--
+	summarizedCopy.root = newRoot
+	return &summarizedCopy, true
+}
+
+// `Alter(value, f)` is the summarized counterpart of `RBTree.Alter(value, f)`.
+// CAUTION: The `Just` value returned by `f` must be equal to `value`.
+func (summarized *Summarized[Value, Summary]) Alter(value Value, f func(old maybe.Maybe[Value]) maybe.Maybe[Value]) (newTree *Summarized[Value, Summary], old maybe.Maybe[Value]) {
+
+	newRoot, oldValue, change := summarized.root.alter(summarized.cmp, value, f, summarized.doubleBlackLeaf, summarized.editor)
+	if change == alterationNone {
+		return summarized, maybe.FromGoPointer(oldValue)
+	}
+
+	summarizedCopy := *summarized
+	switch change {
+	case alterationInsertion:
+		summarizedCopy.cnt++
+	case alterationDeletion:
+		summarizedCopy.cnt--
+	}
+
+	summarizedCopy.root = newRoot
+	return &summarizedCopy, maybe.FromGoPointer(oldValue)
+}
+
+// `withSummaries(n, e)` copies `n` as it is, except that all nodes carry summaries computed by `e`.
+func withSummaries[Value any, Summary any](n *node[Value, noSummary], e *editor[Value, Summary]) *node[Value, Summary] {
+
+	if n == nil {
+		return nil
+	}
+
+	return newNode(
+		[directionNum]*node[Value, Summary]{
+			directionLeft:  withSummaries(n.children[directionLeft], e),
+			directionRight: withSummaries(n.children[directionRight], e),
+		},
+		n.color,
+		n.value,
+		e,
+	)
+}
+
+func (monoid *Monoid[Value, Summary]) summaryOf(n *node[Value, Summary]) Summary {
+	if n == nil {
+		return monoid.Empty
+	}
+	return n.summary
+}
+
+// Once `n` lies in the range, each bound only matters to the subtree on its own side,
+// so at most two paths are walked down.
+func (monoid *Monoid[Value, Summary]) fold(cmp comparator.Comparator[Value], n *node[Value, Summary], bounds [directionNum]maybe.Maybe[Bound[Value]]) Summary {
+
+	switch {
+	case n == nil:
+		return monoid.Empty
+	case bounds[directionLeft].IsNothing() && bounds[directionRight].IsNothing():
+		return monoid.summaryOf(n)
+	case !admits(cmp, bounds[directionLeft], directionLeft, n.value):
+		return monoid.fold(cmp, n.children[directionRight], bounds)
+	case !admits(cmp, bounds[directionRight], directionRight, n.value):
+		return monoid.fold(cmp, n.children[directionLeft], bounds)
+	}
+
+	return monoid.Combine(
+		monoid.Combine(
+			monoid.fold(cmp, n.children[directionLeft], [directionNum]maybe.Maybe[Bound[Value]]{directionLeft: bounds[directionLeft]}),
+			monoid.Measure(n.value),
+		),
+		monoid.fold(cmp, n.children[directionRight], [directionNum]maybe.Maybe[Bound[Value]]{directionRight: bounds[directionRight]}),
+	)
+}
+
+// Returning false means that `yield` stopped the iteration.
+func (monoid *Monoid[Value, Summary]) search(n *node[Value, Summary], admit func(Summary) bool, yield func(Value) bool) bool {
+
+	if n == nil || !admit(monoid.summaryOf(n)) {
+		return true
+	}
+
+	return monoid.search(n.children[directionLeft], admit, yield) &&
+		(!admit(monoid.Measure(n.value)) || yield(n.value)) &&
+		monoid.search(n.children[directionRight], admit, yield)
+}
@@ -0,0 +1,137 @@
+package immutable_rb_tree
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+// `sumMonoid` sums up values, whose summaries are easy to verify.
+var sumMonoid = Monoid[int, int]{
+	Empty:   0,
+	Combine: func(l int, r int) int { return l + r },
+	Measure: func(value int) int { return value },
+}
+
+func sum(xs []int) int {
+	res := 0
+	for _, x := range xs {
+		res += x
+	}
+	return res
+}
+
+// `isSummarized()` reports whether the summary of every node under `n` is up to date.
+func isSummarized[Value any, Summary comparable](n *node[Value, Summary], monoid Monoid[Value, Summary]) (Summary, bool) {
+
+	if n == nil {
+		return monoid.Empty, true
+	}
+
+	l, lok := isSummarized(n.children[directionLeft], monoid)
+	r, rok := isSummarized(n.children[directionRight], monoid)
+	summary := monoid.Combine(monoid.Combine(l, monoid.Measure(n.value)), r)
+	return summary, lok && rok && n.summary == summary
+}
+
+// `isHealthy()` reports whether `summarized` is a valid red-black tree with up-to-date summaries.
+func isHealthy[Value any, Summary comparable](summarized *Summarized[Value, Summary], monoid Monoid[Value, Summary]) bool {
+	_, ok := isSummarized(summarized.root, monoid)
+	return summarized.Validate() == nil && ok
+}
+
+func TestSummarize(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"summaries should be maintained by insertions and deletions": func(xs []int8, ys []int8) bool {
+
+			summarized := NewSummarized(comparator.OrderedComparator[int], sumMonoid)
+			for _, x := range intsFromInt8s(xs) {
+				summarized, _ = summarized.Insert(x)
+			}
+			for _, y := range intsFromInt8s(ys) {
+				summarized, _ = summarized.Delete(y)
+			}
+
+			return isHealthy(summarized, sumMonoid) && summarized.Summary() == sum(slices.Collect(summarized.All()))
+		},
+		"summaries should be maintained by alterations without affecting the original tree": func(xs []int8, ys []int8) bool {
+
+			summarized := Summarize(FromValues(comparator.OrderedComparator[int], intsFromInt8s(xs)...), sumMonoid)
+
+			newTree := summarized
+			for _, y := range intsFromInt8s(ys) {
+				newTree, _ = newTree.Alter(y, func(old maybe.Maybe[int]) maybe.Maybe[int] {
+					if old.IsJust() {
+						return maybe.Nothing[int]()
+					}
+					return maybe.Just(y)
+				})
+			}
+
+			return isHealthy(newTree, sumMonoid) && isHealthy(summarized, sumMonoid) &&
+				newTree.Summary() == sum(slices.Collect(newTree.All()))
+		},
+		"summarize(rbTree) should keep all values of rbTree": func(xs []int8) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], intsFromInt8s(xs)...)
+			summarized := Summarize(rbTree, sumMonoid)
+
+			return isHealthy(summarized, sumMonoid) &&
+				summarized.Count() == rbTree.Count() &&
+				slices.Equal(slices.Collect(summarized.All()), rbTree.Values())
+		},
+	})
+}
+
+func TestSearch(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"search(max >= x) == filter(>= x)": func(xs []int8, x int8) bool {
+
+			maxMonoid := Monoid[int, int]{
+				Empty:   -1 << 8,
+				Combine: func(l int, r int) int { return max(l, r) },
+				Measure: func(value int) int { return value },
+			}
+			rbTree := FromValues(comparator.OrderedComparator[int], intsFromInt8s(xs)...)
+			summarized := Summarize(rbTree, maxMonoid)
+
+			return slices.Equal(
+				slices.Collect(summarized.Search(func(summary int) bool { return summary >= int(x) })),
+				slices.Collect(rbTree.From(Inclusive(int(x)))),
+			)
+		},
+	})
+}
+
+func TestFold(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fold(lo, hi) == sum(range(lo, hi))": func(xs []int8, ys []int8, lo int8, hi int8, loInclusive bool, hiInclusive bool) bool {
+
+			summarized := Summarize(FromValues(comparator.OrderedComparator[int], intsFromInt8s(xs)...), sumMonoid)
+			for _, y := range intsFromInt8s(ys) {
+				summarized, _ = summarized.Delete(y)
+			}
+
+			loBound := Bound[int]{Value: int(lo), Inclusive: loInclusive}
+			hiBound := Bound[int]{Value: int(hi), Inclusive: hiInclusive}
+			return summarized.Fold(loBound, hiBound) == sum(slices.Collect(summarized.Range(loBound, hiBound)))
+		},
+		"fold(min, max) == summary()": func(xs []int8) bool {
+
+			summarized := Summarize(FromValues(comparator.OrderedComparator[int], intsFromInt8s(xs)...), sumMonoid)
+
+			return summarized.Fold(Inclusive(-1<<7), Inclusive(1<<7-1)) == summarized.Summary()
+		},
+	})
+}
+
+func intsFromInt8s(xs []int8) []int {
+	res := make([]int, 0, len(xs))
+	for _, x := range xs {
+		res = append(res, int(x))
+	}
+	return res
+}
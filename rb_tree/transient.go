@@ -0,0 +1,107 @@
+package immutable_rb_tree
+
+import (
+	"iter"
+
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+type (
+	// `Transient` is a mutable builder of `RBTree`, like the transient data structures of Clojure,
+	// nodes created by a `Transient` are owned by it and will be edited in place instead of being copied.
+	// CAUTION: `Transient` is not safe for concurrent use.
+	Transient[Value any] struct {
+		rbTree RBTree[Value]
+		editor *editor[Value, noSummary]
+	}
+
+	// Never compare zero-sized pointers, so that `editToken` must not be an empty struct.
+	editToken struct {
+		_ int8
+	}
+)
+
+// `Transient()` returns a mutable builder starting with all values of `rbTree`, `rbTree` itself is never affected.
+func (rbTree *RBTree[Value]) Transient() *Transient[Value] {
+	return &Transient[Value]{
+		rbTree: *rbTree,
+		editor: &editor[Value, noSummary]{edit: &editToken{}},
+	}
+}
+
+// `Persistent()` returns an `RBTree` of all values so far,
+// which will never be affected by any subsequent modification to `transient`.
+func (transient *Transient[Value]) Persistent() *RBTree[Value] {
+	// renew the ownership to freeze all nodes owned so far.
+	transient.editor = &editor[Value, noSummary]{edit: &editToken{}}
+	rbTree := transient.rbTree
+	return &rbTree
+}
+
+func (transient *Transient[Value]) Empty() bool {
+	return transient.rbTree.Empty()
+}
+
+func (transient *Transient[Value]) Count() int {
+	return transient.rbTree.Count()
+}
+
+// Unlike `RBTree.Lookup()`, the value found is copied since it might be edited in place later.
+func (transient *Transient[Value]) Lookup(value Value) maybe.Maybe[Value] {
+	res := transient.rbTree.Lookup(value)
+	if res == nil {
+		return maybe.Nothing[Value]()
+	}
+	return maybe.Just(*res)
+}
+
+// CAUTION: Never modify `transient` during the iteration.
+func (transient *Transient[Value]) All() iter.Seq[Value] {
+	return transient.rbTree.All()
+}
+
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+func (transient *Transient[Value]) Insert(value Value) (affected bool) {
+
+	rbTree := &transient.rbTree
+
+	newRoot, affected := rbTree.root.insert(rbTree.cmp, value, transient.editor)
+	if affected {
+		rbTree.cnt++
+	}
+
+	rbTree.root = newRoot
+	return affected
+}
+
+// `affected` is true, meaning that a real deletion occurred; otherwise nothing happens.
+func (transient *Transient[Value]) Delete(value Value) (affected bool) {
+
+	rbTree := &transient.rbTree
+
+	newRoot, affected := rbTree.root.delete(rbTree.cmp, value, rbTree.doubleBlackLeaf, transient.editor)
+	if affected {
+		rbTree.cnt--
+	}
+
+	rbTree.root = newRoot
+	return affected
+}
+
+// `Alter(value, f)` is the in-place counterpart of `RBTree.Alter(value, f)`.
+// CAUTION: The `Just` value returned by `f` must be equal to `value`.
+func (transient *Transient[Value]) Alter(value Value, f func(old maybe.Maybe[Value]) maybe.Maybe[Value]) (old maybe.Maybe[Value]) {
+
+	rbTree := &transient.rbTree
+
+	newRoot, oldValue, change := rbTree.root.alter(rbTree.cmp, value, f, rbTree.doubleBlackLeaf, transient.editor)
+	switch change {
+	case alterationInsertion:
+		rbTree.cnt++
+	case alterationDeletion:
+		rbTree.cnt--
+	}
+
+	rbTree.root = newRoot
+	return maybe.FromGoPointer(oldValue)
+}
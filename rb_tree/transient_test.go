@@ -0,0 +1,79 @@
+package immutable_rb_tree
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
+)
+
+func TestTransient(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"transient insertions and deletions should be the same as persistent ones": func(xs []int8, ys []int8) bool {
+
+			cmp := comparator.OrderedComparator[int8]
+
+			rbTree := New(cmp)
+			transient := rbTree.Transient()
+			for _, x := range xs {
+				rbTree, _ = rbTree.Insert(x)
+				transient.Insert(x)
+			}
+			for _, y := range ys {
+				rbTree, _ = rbTree.Delete(y)
+				transient.Delete(y)
+			}
+
+			persistent := transient.Persistent()
+			return slices.Equal(persistent.Values(), rbTree.Values()) &&
+				persistent.Count() == rbTree.Count() &&
+				isBalanced(persistent)
+		},
+		"modifying a transient should never affect the original tree": func(xs []int, ys []int) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int], xs...)
+			values := rbTree.Values()
+
+			transient := rbTree.Transient()
+			for _, y := range ys {
+				transient.Insert(y)
+			}
+			for _, x := range xs {
+				transient.Delete(x)
+			}
+
+			return slices.Equal(rbTree.Values(), values) && rbTree.Count() == len(values)
+		},
+		"modifying a transient should never affect trees it has returned": func(xs []int, ys []int) bool {
+
+			transient := New(comparator.OrderedComparator[int]).Transient()
+			for _, x := range xs {
+				transient.Insert(x)
+			}
+
+			rbTree := transient.Persistent()
+			values := rbTree.Values()
+
+			for _, y := range ys {
+				transient.Insert(y)
+			}
+			for _, x := range xs {
+				transient.Alter(x, func(old maybe.Maybe[int]) maybe.Maybe[int] { return maybe.Nothing[int]() })
+			}
+
+			return slices.Equal(rbTree.Values(), values) && isBalanced(transient.Persistent())
+		},
+		"transient.alter(x, f) returns the old value": func(xs []int, x int) bool {
+
+			transient := FromValues(comparator.OrderedComparator[int], xs...).Transient()
+			expected := transient.Lookup(x)
+
+			old := transient.Alter(x, func(old maybe.Maybe[int]) maybe.Maybe[int] { return maybe.Just(x) })
+			return old.IsJust() == expected.IsJust() &&
+				transient.Lookup(x).OrValue(x+1) == x &&
+				isBalanced(transient.Persistent())
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package immutable_rb_tree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/freebirdljj/immutable/comparator"
+)
+
+var (
+	ErrRedRoot         = errors.New("root is red")
+	ErrUnsettledColor  = errors.New("node is left double-black or negative-black")
+	ErrDoubleBlackLeaf = errors.New("double-black leaf is left in tree")
+	ErrRedRed          = errors.New("red node has a red child")
+	ErrBlackHeight     = errors.New("black heights of subtrees differ")
+	ErrOrder           = errors.New("values are out of order")
+	ErrCount           = errors.New("count is inconsistent")
+)
+
+// `Validate()` checks the invariants of `rbTree` and returns the first violation found, wrapping one of the `Err*` errors,
+// or nil if `rbTree` is healthy. It costs O(n), so it is meant for tests and debugging.
+func (rbTree *RBTree[Value]) Validate() error {
+	return validateTree(rbTree.cmp, rbTree.doubleBlackLeaf, rbTree.cnt, rbTree.root)
+}
+
+// `Validate()` is the summarized counterpart of `RBTree.Validate()`, which never checks summaries.
+func (summarized *Summarized[Value, Summary]) Validate() error {
+	return validateTree(summarized.cmp, summarized.doubleBlackLeaf, summarized.cnt, summarized.root)
+}
+
+func validateTree[Value any, Summary any](cmp comparator.Comparator[Value], doubleBlackLeaf *node[Value, Summary], cnt int, root *node[Value, Summary]) error {
+
+	if root.getColor() == colorRed {
+		return ErrRedRoot
+	}
+
+	if _, err := root.validate(cmp, doubleBlackLeaf, nil, nil); err != nil {
+		return err
+	}
+
+	if cnt != root.count() {
+		return fmt.Errorf("%w: tree has %d values but its root counts %d", ErrCount, cnt, root.count())
+	}
+
+	return nil
+}
+
+// `validate()` returns the black height of `n`, all values of which must be greater than `*lo` and less than `*hi` if given.
+func (n *node[Value, Summary]) validate(cmp comparator.Comparator[Value], doubleBlackLeaf *node[Value, Summary], lo *Value, hi *Value) (bh int, err error) {
+
+	if n == nil {
+		return 0, nil
+	}
+
+	if n == doubleBlackLeaf {
+		return 0, ErrDoubleBlackLeaf
+	}
+
+	if (lo != nil && cmp(*lo, n.value) >= 0) || (hi != nil && cmp(n.value, *hi) >= 0) {
+		return 0, fmt.Errorf("%w: at %v", ErrOrder, n.value)
+	}
+
+	lbh, err := n.children[directionLeft].validate(cmp, doubleBlackLeaf, lo, &n.value)
+	if err != nil {
+		return 0, err
+	}
+
+	rbh, err := n.children[directionRight].validate(cmp, doubleBlackLeaf, &n.value, hi)
+	if err != nil {
+		return 0, err
+	}
+
+	if lbh != rbh {
+		return 0, fmt.Errorf("%w: %d on the left and %d on the right of %v", ErrBlackHeight, lbh, rbh, n.value)
+	}
+
+	if cnt := n.children[directionLeft].count() + 1 + n.children[directionRight].count(); n.cnt != cnt {
+		return 0, fmt.Errorf("%w: %v counts %d but has %d values", ErrCount, n.value, n.cnt, cnt)
+	}
+
+	switch n.color {
+	case colorBlack:
+		return lbh + 1, nil
+	case colorRed:
+		if n.children[directionLeft].getColor() == colorRed || n.children[directionRight].getColor() == colorRed {
+			return 0, fmt.Errorf("%w: at %v", ErrRedRed, n.value)
+		}
+		return lbh, nil
+	default:
+		return 0, fmt.Errorf("%w: %v is %v", ErrUnsettledColor, n.value, n.color)
+	}
+}
@@ -0,0 +1,60 @@
+package immutable_rb_tree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+func TestRBTreeValidate(t *testing.T) {
+
+	quick.CheckProperties(t, map[string]any{
+		"trees built by insertions and deletions should be valid": func(xs []int8, ys []int8) bool {
+
+			rbTree := FromValues(comparator.OrderedComparator[int8], xs...)
+			if rbTree.Validate() != nil {
+				return false
+			}
+
+			for _, y := range ys {
+				rbTree, _ = rbTree.Delete(y)
+				if rbTree.Validate() != nil {
+					return false
+				}
+			}
+			return true
+		},
+	})
+
+	leaf := func(value int, color color) *node[int, noSummary] {
+		return newNode([directionNum]*node[int, noSummary]{}, color, value, nil)
+	}
+	branch := func(l *node[int, noSummary], value int, color color, r *node[int, noSummary]) *node[int, noSummary] {
+		return newNode([directionNum]*node[int, noSummary]{directionLeft: l, directionRight: r}, color, value, nil)
+	}
+
+	rbTree := New(comparator.OrderedComparator[int])
+	brokenCount := branch(leaf(1, colorRed), 2, colorBlack, nil)
+	brokenCount.cnt = 3
+
+	for name, testCase := range map[string]struct {
+		root     *node[int, noSummary]
+		expected error
+	}{
+		"red root":          {root: leaf(1, colorRed), expected: ErrRedRoot},
+		"red-red":           {root: branch(branch(leaf(1, colorRed), 2, colorRed, nil), 3, colorBlack, nil), expected: ErrRedRed},
+		"black height":      {root: branch(leaf(1, colorBlack), 2, colorBlack, nil), expected: ErrBlackHeight},
+		"order":             {root: branch(leaf(3, colorRed), 2, colorBlack, nil), expected: ErrOrder},
+		"duplicate":         {root: branch(leaf(2, colorRed), 2, colorBlack, nil), expected: ErrOrder},
+		"double-black":      {root: branch(nil, 1, colorBlack, leaf(2, colorDoubleBlack)), expected: ErrUnsettledColor},
+		"negative-black":    {root: branch(nil, 1, colorBlack, leaf(2, colorNegativeBlack)), expected: ErrUnsettledColor},
+		"double-black leaf": {root: branch(nil, 1, colorBlack, rbTree.doubleBlackLeaf), expected: ErrDoubleBlackLeaf},
+		"count":             {root: brokenCount, expected: ErrCount},
+	} {
+		if err := rbTree.withRoot(testCase.root).Validate(); !errors.Is(err, testCase.expected) {
+			t.Errorf("%s: expected %v, got %v", name, testCase.expected, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package immutable_set
+
+import (
+	"github.com/freebirdljj/immutable/comparator"
+	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
+)
+
+type (
+	// `Builder` is a mutable builder of `Set` for batch modifications.
+	// CAUTION: `Builder` is not safe for concurrent use.
+	Builder[Value any] immutable_rb_tree.Transient[Value]
+)
+
+func NewBuilder[Value any](cmp comparator.Comparator[Value]) *Builder[Value] {
+	return New(cmp).Transient()
+}
+
+// `Transient()` returns a mutable builder starting with all values of `s`, `s` itself is never affected.
+func (s *Set[Value]) Transient() *Builder[Value] {
+	return (*Builder[Value])(s.rbTree().Transient())
+}
+
+// `Persistent()` returns a `Set` of all values so far,
+// which will never be affected by any subsequent modification to `b`.
+func (b *Builder[Value]) Persistent() *Set[Value] {
+	return (*Set[Value])(b.transient().Persistent())
+}
+
+func (b *Builder[Value]) Empty() bool {
+	return b.transient().Empty()
+}
+
+func (b *Builder[Value]) Count() int {
+	return b.transient().Count()
+}
+
+func (b *Builder[Value]) Has(value Value) bool {
+	return b.transient().Lookup(value).IsJust()
+}
+
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+func (b *Builder[Value]) Insert(value Value) (affected bool) {
+	return b.transient().Insert(value)
+}
+
+// `affected` is true, meaning that a real deletion occurred; otherwise nothing happens.
+func (b *Builder[Value]) Delete(value Value) (affected bool) {
+	return b.transient().Delete(value)
+}
+
+func (b *Builder[Value]) transient() *immutable_rb_tree.Transient[Value] {
+	return (*immutable_rb_tree.Transient[Value])(b)
+}
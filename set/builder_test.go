@@ -0,0 +1,45 @@
+package immutable_set
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/comparator"
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+func TestBuilder(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"building from values should be the same as fromValues(values)": func(xs []string, ys []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+
+			b := NewBuilder(cmp)
+			for _, x := range xs {
+				b.Insert(x)
+			}
+			for _, y := range ys {
+				b.Delete(y)
+			}
+
+			return b.Persistent().Equal(FromValues(cmp, xs...).Difference(FromValues(cmp, ys...)))
+		},
+		"modifying a builder should never affect the original set": func(xs []string, ys []string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+			values := s.Values()
+
+			b := s.Transient()
+			for _, y := range ys {
+				if had := b.Has(y); b.Insert(y) == had {
+					return false
+				}
+			}
+			for _, x := range xs {
+				b.Delete(x)
+			}
+
+			return slices.Equal(s.Values(), values) && b.Count() == FromValues(comparator.OrderedComparator[string], ys...).Difference(s).Count()
+		},
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"iter"
 
 	"github.com/freebirdljj/immutable/comparator"
+	immutable_func "github.com/freebirdljj/immutable/func"
+	"github.com/freebirdljj/immutable/maybe"
 	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
 )
 
@@ -16,14 +18,77 @@ func New[Value any](cmp comparator.Comparator[Value]) *Set[Value] {
 	return (*Set[Value])(immutable_rb_tree.New(cmp))
 }
 
+// Among equal values, the last one is preserved.
 func FromValues[Value any](cmp comparator.Comparator[Value], values ...Value) *Set[Value] {
 	return (*Set[Value])(immutable_rb_tree.FromValues(cmp, values...))
 }
 
+// `FromSeq()` sorts all values of `seq` and then builds the set in linear time.
+// Among equal values, the last one is preserved.
+func FromSeq[Value any](cmp comparator.Comparator[Value], seq iter.Seq[Value]) *Set[Value] {
+	return (*Set[Value])(immutable_rb_tree.FromSeq(cmp, seq))
+}
+
+// `FromSortedValues()` builds the set in linear time.
+// Among equal values, the last one is preserved.
+// CAUTION: Only invoke `FromSortedValues` with `values` sorted in ascending order.
+func FromSortedValues[Value any](cmp comparator.Comparator[Value], values ...Value) *Set[Value] {
+	return (*Set[Value])(immutable_rb_tree.FromSortedValues(cmp, values...))
+}
+
+// `FromSortedSeq()` builds the set in linear time.
+// Among equal values, the last one is preserved.
+// CAUTION: Only invoke `FromSortedSeq` with finite `seq` sorted in ascending order.
+func FromSortedSeq[Value any](cmp comparator.Comparator[Value], seq iter.Seq[Value]) *Set[Value] {
+	return (*Set[Value])(immutable_rb_tree.FromSortedSeq(cmp, seq))
+}
+
+// `Diff(oldSet, newSet)` returns an iterator of values added to or removed from `oldSet` to obtain `newSet` in ascending order,
+// skipping subsets shared by both, which makes diffing versions derived from each other cheap.
+// CAUTION: Only invoke `Diff` with sets sharing the same comparator.
+func Diff[Value any](oldSet *Set[Value], newSet *Set[Value]) iter.Seq[immutable_rb_tree.Change[Value]] {
+	return immutable_rb_tree.Diff(oldSet.rbTree(), newSet.rbTree(), nil)
+}
+
 func (s *Set[Value]) All() iter.Seq[Value] {
 	return s.rbTree().All()
 }
 
+// `Range(lo, hi)` returns an iterator of all values between `lo` and `hi` in ascending order.
+func (s *Set[Value]) Range(lo immutable_rb_tree.Bound[Value], hi immutable_rb_tree.Bound[Value]) iter.Seq[Value] {
+	return s.rbTree().Range(lo, hi)
+}
+
+// `From(lo)` returns an iterator of all values above `lo` in ascending order.
+func (s *Set[Value]) From(lo immutable_rb_tree.Bound[Value]) iter.Seq[Value] {
+	return s.rbTree().From(lo)
+}
+
+// `UpTo(hi)` returns an iterator of all values below `hi` in ascending order.
+func (s *Set[Value]) UpTo(hi immutable_rb_tree.Bound[Value]) iter.Seq[Value] {
+	return s.rbTree().UpTo(hi)
+}
+
+// `Backward()` returns an iterator of all values in descending order.
+func (s *Set[Value]) Backward() iter.Seq[Value] {
+	return s.rbTree().Backward()
+}
+
+// `BackwardRange(lo, hi)` returns an iterator of all values between `lo` and `hi` in descending order.
+func (s *Set[Value]) BackwardRange(lo immutable_rb_tree.Bound[Value], hi immutable_rb_tree.Bound[Value]) iter.Seq[Value] {
+	return s.rbTree().BackwardRange(lo, hi)
+}
+
+// `BackwardFrom(lo)` returns an iterator of all values above `lo` in descending order.
+func (s *Set[Value]) BackwardFrom(lo immutable_rb_tree.Bound[Value]) iter.Seq[Value] {
+	return s.rbTree().BackwardFrom(lo)
+}
+
+// `BackwardUpTo(hi)` returns an iterator of all values below `hi` in descending order.
+func (s *Set[Value]) BackwardUpTo(hi immutable_rb_tree.Bound[Value]) iter.Seq[Value] {
+	return s.rbTree().BackwardUpTo(hi)
+}
+
 func (s *Set[Value]) Values() []Value {
 	return s.rbTree().Values()
 }
@@ -40,6 +105,65 @@ func (s *Set[Value]) Has(value Value) bool {
 	return s.rbTree().Lookup(value) != nil
 }
 
+func (s *Set[Value]) Max() maybe.Maybe[Value] {
+	return s.rbTree().Max()
+}
+
+func (s *Set[Value]) Min() maybe.Maybe[Value] {
+	return s.rbTree().Min()
+}
+
+// `PopMax()` removes the maximum, `newSet` is the original one if the set is empty.
+func (s *Set[Value]) PopMax() (newSet *Set[Value], max maybe.Maybe[Value]) {
+	newRBTree, max := s.rbTree().PopMax()
+	return (*Set[Value])(newRBTree), max
+}
+
+// `PopMin()` removes the minimum, `newSet` is the original one if the set is empty.
+func (s *Set[Value]) PopMin() (newSet *Set[Value], min maybe.Maybe[Value]) {
+	newRBTree, min := s.rbTree().PopMin()
+	return (*Set[Value])(newRBTree), min
+}
+
+// `Floor(value)` returns the greatest value less than or equal to `value`.
+func (s *Set[Value]) Floor(value Value) maybe.Maybe[Value] {
+	return s.rbTree().Floor(value)
+}
+
+// `Ceiling(value)` returns the least value greater than or equal to `value`.
+func (s *Set[Value]) Ceiling(value Value) maybe.Maybe[Value] {
+	return s.rbTree().Ceiling(value)
+}
+
+// `Lower(value)` returns the greatest value strictly less than `value`.
+func (s *Set[Value]) Lower(value Value) maybe.Maybe[Value] {
+	return s.rbTree().Lower(value)
+}
+
+// `Higher(value)` returns the least value strictly greater than `value`.
+func (s *Set[Value]) Higher(value Value) maybe.Maybe[Value] {
+	return s.rbTree().Higher(value)
+}
+
+// `At(k)` returns the k-th (0-indexed) smallest value, `has` is false if `k` is out of range.
+func (s *Set[Value]) At(k int) (value Value, has bool) {
+	v := s.rbTree().At(k)
+	if v == nil {
+		return immutable_func.Zero[Value](), false
+	}
+	return *v, true
+}
+
+// `Rank(value)` returns the number of values which are less than `value`.
+func (s *Set[Value]) Rank(value Value) int {
+	return s.rbTree().Rank(value)
+}
+
+// `CountRange(lo, hi)` returns the number of values in the half-open range [lo, hi).
+func (s *Set[Value]) CountRange(lo Value, hi Value) int {
+	return s.rbTree().CountRange(lo, hi)
+}
+
 // `newSet` returned by `Insert()` is always different from the original one.
 // `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
 func (s *Set[Value]) Insert(value Value) (newSet *Set[Value], affected bool) {
@@ -54,6 +178,39 @@ func (s *Set[Value]) Delete(value Value) (newSet *Set[Value], affected bool) {
 	return (*Set[Value])(newRBTree), affected
 }
 
+// `Union(other)` returns a set of values in either `s` or `other`, values of `s` are preferred when equal.
+// CAUTION: Only invoke set operations with sets sharing the same comparator.
+func (s *Set[Value]) Union(other *Set[Value]) *Set[Value] {
+	return (*Set[Value])(s.rbTree().Union(other.rbTree()))
+}
+
+// `Intersection(other)` returns a set of values in both `s` and `other`, values of `s` are preferred.
+func (s *Set[Value]) Intersection(other *Set[Value]) *Set[Value] {
+	return (*Set[Value])(s.rbTree().Intersection(other.rbTree()))
+}
+
+// `Difference(other)` returns a set of values in `s` but not in `other`.
+func (s *Set[Value]) Difference(other *Set[Value]) *Set[Value] {
+	return (*Set[Value])(s.rbTree().Difference(other.rbTree()))
+}
+
+// `SymmetricDifference(other)` returns a set of values in exactly one of `s` and `other`.
+func (s *Set[Value]) SymmetricDifference(other *Set[Value]) *Set[Value] {
+	return (*Set[Value])(s.rbTree().SymmetricDifference(other.rbTree()))
+}
+
+func (s *Set[Value]) IsSubsetOf(other *Set[Value]) bool {
+	return s.rbTree().IsSubsetOf(other.rbTree())
+}
+
+func (s *Set[Value]) IsDisjoint(other *Set[Value]) bool {
+	return s.rbTree().IsDisjoint(other.rbTree())
+}
+
+func (s *Set[Value]) Equal(other *Set[Value]) bool {
+	return s.rbTree().Equal(other.rbTree())
+}
+
 func (s *Set[Value]) rbTree() *immutable_rb_tree.RBTree[Value] {
 	return ((*immutable_rb_tree.RBTree[Value])(s))
 }
@@ -1,10 +1,12 @@
 package immutable_set
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/freebirdljj/immutable/comparator"
 	"github.com/freebirdljj/immutable/internal/quick"
+	immutable_rb_tree "github.com/freebirdljj/immutable/rb_tree"
 )
 
 func TestSetInsert(t *testing.T) {
@@ -60,3 +62,246 @@ func TestSetDelete(t *testing.T) {
 		},
 	})
 }
+
+func TestSetAt(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"s.at(s.rank(x)) == x if s.has(x)": func(xs []string, x string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], append(xs, x)...)
+
+			value, has := s.At(s.Rank(x))
+			return has && value == x
+		},
+		"s.at(s.count()) should be absent": func(xs []string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+
+			_, has := s.At(s.Count())
+			return !has
+		},
+	})
+}
+
+func TestSetCountRange(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"s.countRange(lo, hi) == s.rank(hi) - s.rank(lo) if lo < hi": func(xs []string, lo string, hi string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			return s.CountRange(lo, hi) == s.Rank(hi)-s.Rank(lo)
+		},
+	})
+}
+
+func TestSetRange(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"s.range(lo, hi) == filter(in range, s.values())": func(xs []string, lo string, hi string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+
+			expected := []string(nil)
+			for _, value := range s.Values() {
+				if lo <= value && value < hi {
+					expected = append(expected, value)
+				}
+			}
+
+			return slices.Equal(
+				slices.Collect(s.Range(immutable_rb_tree.Inclusive(lo), immutable_rb_tree.Exclusive(hi))),
+				expected,
+			)
+		},
+		"s.upTo(lo) ++ s.from(lo) == s.values() if bounds are complementary": func(xs []string, lo string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+
+			return slices.Equal(
+				append(slices.Collect(s.UpTo(immutable_rb_tree.Exclusive(lo))), slices.Collect(s.From(immutable_rb_tree.Inclusive(lo)))...),
+				s.Values(),
+			)
+		},
+	})
+}
+
+func TestSetBackward(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"s.backward() == reverse(s.values())": func(xs []string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+
+			values := s.Values()
+			slices.Reverse(values)
+			return slices.Equal(slices.Collect(s.Backward()), values)
+		},
+		"s.backwardUpTo(hi) ++ s.backwardFrom(hi) == reverse(s.values()) if bounds are complementary": func(xs []string, hi string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+
+			values := s.Values()
+			slices.Reverse(values)
+			return slices.Equal(
+				append(slices.Collect(s.BackwardFrom(immutable_rb_tree.Inclusive(hi))), slices.Collect(s.BackwardUpTo(immutable_rb_tree.Exclusive(hi)))...),
+				values,
+			)
+		},
+	})
+}
+
+func TestSetFloorAndCeiling(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"s.lower(x) < x <= s.ceiling(x) and s.floor(x) <= x < s.higher(x)": func(xs []string, x string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+
+			return (s.Lower(x).IsNothing() || s.Lower(x).Value() < x) &&
+				(s.Ceiling(x).IsNothing() || s.Ceiling(x).Value() >= x) &&
+				(s.Floor(x).IsNothing() || s.Floor(x).Value() <= x) &&
+				(s.Higher(x).IsNothing() || s.Higher(x).Value() > x)
+		},
+	})
+}
+
+func TestSetPopMin(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"popping min repeatedly drains s in ascending order": func(xs []string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+			values := s.Values()
+
+			popped := []string(nil)
+			for min := s.Min(); min.IsJust(); min = s.Min() {
+				newS, popMin := s.PopMin()
+				if popMin.Value() != min.Value() {
+					return false
+				}
+				popped = append(popped, popMin.Value())
+				s = newS
+			}
+
+			return s.Empty() && slices.Equal(popped, values)
+		},
+	})
+}
+
+func TestSetUnion(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"union(xs, ys).has(x) == xs.has(x) || ys.has(x)": func(xs []string, ys []string, x string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			return xSet.Union(ySet).Has(x) == (xSet.Has(x) || ySet.Has(x))
+		},
+		"xs.isSubsetOf(union(xs, ys)) && ys.isSubsetOf(union(xs, ys))": func(xs []string, ys []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, xs...), FromValues(cmp, ys...)
+			union := xSet.Union(ySet)
+
+			return xSet.IsSubsetOf(union) && ySet.IsSubsetOf(union)
+		},
+	})
+}
+
+func TestSetIntersection(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"intersection(xs, ys).has(x) == xs.has(x) && ys.has(x)": func(xs []string, ys []string, x string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, append(xs, x)...), FromValues(cmp, ys...)
+
+			return xSet.Intersection(ySet).Has(x) == ySet.Has(x)
+		},
+		"intersection(xs, ys).isDisjoint(symmetricDifference(xs, ys))": func(xs []string, ys []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			return xSet.Intersection(ySet).IsDisjoint(xSet.SymmetricDifference(ySet))
+		},
+	})
+}
+
+func TestSetDifference(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"difference(xs, ys).has(x) == xs.has(x) && !ys.has(x)": func(xs []string, ys []string, x string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, xs...), FromValues(cmp, append(ys, x)...)
+
+			return !xSet.Difference(ySet).Has(x) && xSet.Difference(ySet).IsDisjoint(ySet)
+		},
+		"symmetricDifference(xs, ys) == union(difference(xs, ys), difference(ys, xs))": func(xs []string, ys []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			return xSet.SymmetricDifference(ySet).Equal(xSet.Difference(ySet).Union(ySet.Difference(xSet)))
+		},
+	})
+}
+
+func TestSetDiff(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"applying diff(xs, ys) to xs should result in ys": func(xs []string, ys []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			s := xSet
+			for change := range Diff(xSet, ySet) {
+				if change.New.IsJust() {
+					s, _ = s.Insert(change.New.Value())
+				} else {
+					s, _ = s.Delete(change.Old.Value())
+				}
+			}
+
+			return s.Equal(ySet)
+		},
+		"diff(s, s.insert(x)) only reports x": func(xs []string, x string) bool {
+
+			s := FromValues(comparator.OrderedComparator[string], xs...)
+			newSet, affected := s.Insert(x)
+
+			changes := slices.Collect(Diff(s, newSet))
+			return affected == (len(changes) == 1) && (!affected || changes[0].New.Value() == x)
+		},
+	})
+}
+
+func TestSetEqual(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromValues(xs).equal(fromValues(reverse(xs)))": func(xs []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			reversed := slices.Clone(xs)
+			slices.Reverse(reversed)
+
+			return FromValues(cmp, xs...).Equal(FromValues(cmp, reversed...))
+		},
+		"xs.equal(ys) == (xs.isSubsetOf(ys) && ys.isSubsetOf(xs))": func(xs []string, ys []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			xSet, ySet := FromValues(cmp, xs...), FromValues(cmp, ys...)
+
+			return xSet.Equal(ySet) == (xSet.IsSubsetOf(ySet) && ySet.IsSubsetOf(xSet))
+		},
+	})
+}
+
+func TestSetFromSortedSeq(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromSortedSeq(s.all()).equal(s)": func(xs []string) bool {
+
+			cmp := comparator.OrderedComparator[string]
+			s := FromSeq(cmp, slices.Values(xs))
+
+			return FromSortedSeq(cmp, s.All()).Equal(s) &&
+				FromSortedValues(cmp, s.Values()...).Equal(s)
+		},
+	})
+}
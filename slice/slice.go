@@ -5,6 +5,7 @@ import (
 
 	"github.com/freebirdljj/immutable/comparator"
 	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
 )
 
 type (
@@ -89,6 +90,50 @@ func Concat[T any](xss Slice[Slice[T]]) Slice[T] {
 	}
 }
 
+func Zip[T1 any, T2 any](xs Slice[T1], ys Slice[T2]) Slice[tuple.Pair[T1, T2]] {
+	return ZipWith(xs, ys, func(x T1, y T2) tuple.Pair[T1, T2] {
+		return tuple.Pair[T1, T2]{First: x, Second: y}
+	})
+}
+
+func ZipWith[T1 any, T2 any, T3 any](xs Slice[T1], ys Slice[T2], f func(T1, T2) T3) Slice[T3] {
+	res := make(Slice[T3], min(len(xs), len(ys)))
+	for i := range res {
+		res[i] = f(xs[i], ys[i])
+	}
+	return res
+}
+
+func Zip3[T1 any, T2 any, T3 any](xs Slice[T1], ys Slice[T2], zs Slice[T3]) Slice[tuple.Triple[T1, T2, T3]] {
+	res := make(Slice[tuple.Triple[T1, T2, T3]], min(len(xs), len(ys), len(zs)))
+	for i := range res {
+		res[i] = tuple.Triple[T1, T2, T3]{First: xs[i], Second: ys[i], Third: zs[i]}
+	}
+	return res
+}
+
+// `ZipLongest(xs, ys)` is like `Zip(xs, ys)`, but pads the shorter one with nothing.
+func ZipLongest[T1 any, T2 any](xs Slice[T1], ys Slice[T2]) Slice[tuple.Pair[maybe.Maybe[T1], maybe.Maybe[T2]]] {
+	res := make(Slice[tuple.Pair[maybe.Maybe[T1], maybe.Maybe[T2]]], max(len(xs), len(ys)))
+	for i := range res {
+		if i < len(xs) {
+			res[i].First = maybe.Just(xs[i])
+		}
+		if i < len(ys) {
+			res[i].Second = maybe.Just(ys[i])
+		}
+	}
+	return res
+}
+
+func Unzip[T1 any, T2 any](xys Slice[tuple.Pair[T1, T2]]) (xs Slice[T1], ys Slice[T2]) {
+	xs, ys = make(Slice[T1], len(xys)), make(Slice[T2], len(xys))
+	for i, xy := range xys {
+		xs[i], ys[i] = xy.First, xy.Second
+	}
+	return xs, ys
+}
+
 func (xs Slice[T]) Empty() bool {
 	return len(xs) == 0
 }
@@ -11,6 +11,8 @@ import (
 	"github.com/freebirdljj/immutable/comparator"
 	immutable_func "github.com/freebirdljj/immutable/func"
 	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/tuple"
 )
 
 func TestMap(t *testing.T) {
@@ -119,6 +121,73 @@ func TestConcat(t *testing.T) {
 	})
 }
 
+func TestZip(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"zip(xs, ys)[i] == (xs[i], ys[i])": func(xs []int, ys []string) bool {
+
+			zipped := Zip(xs, ys)
+			for i, xy := range zipped {
+				if xy.First != xs[i] || xy.Second != ys[i] {
+					return false
+				}
+			}
+			return len(zipped) == min(len(xs), len(ys))
+		},
+		"zipWith(xs, ys, f) == map(zip(xs, ys), f)": func(xs []int, ys []int) bool {
+			return slicesEqual(
+				ZipWith(xs, ys, func(x int, y int) int { return x - y }),
+				Map(Zip(xs, ys), func(xy tuple.Pair[int, int]) int { return xy.First - xy.Second }),
+			)
+		},
+		"zip3(xs, ys, zs)[i] == (xs[i], ys[i], zs[i])": func(xs []int, ys []string, zs []bool) bool {
+
+			zipped := Zip3(xs, ys, zs)
+			for i, xyz := range zipped {
+				if xyz.First != xs[i] || xyz.Second != ys[i] || xyz.Third != zs[i] {
+					return false
+				}
+			}
+			return len(zipped) == min(len(xs), len(ys), len(zs))
+		},
+	})
+}
+
+func TestZipLongest(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"zipLongest(xs, ys) pads the shorter one with nothing": func(xs []int, ys []string) bool {
+
+			expected := Slice[tuple.Pair[maybe.Maybe[int], maybe.Maybe[string]]](nil)
+			for i := range max(len(xs), len(ys)) {
+				pair := tuple.Pair[maybe.Maybe[int], maybe.Maybe[string]]{}
+				if i < len(xs) {
+					pair.First = maybe.Just(xs[i])
+				}
+				if i < len(ys) {
+					pair.Second = maybe.Just(ys[i])
+				}
+				expected = append(expected, pair)
+			}
+
+			return slicesEqual(ZipLongest(xs, ys), expected)
+		},
+	})
+}
+
+func TestUnzip(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"unzip(zip(xs, ys)) == (xs, ys) if len(xs) == len(ys)": func(xys map[int]string) bool {
+
+			xs, ys := Slice[int](nil), Slice[string](nil)
+			for x, y := range xys {
+				xs, ys = append(xs, x), append(ys, y)
+			}
+
+			unzippedX, unzippedY := Unzip(Zip(xs, ys))
+			return slicesEqual(unzippedX, xs) && slicesEqual(unzippedY, ys)
+		},
+	})
+}
+
 func TestSliceAppend(t *testing.T) {
 	quick.CheckProperties(t, map[string]any{
 		"xs.append(elems...) == append(xs, elems...)": func(xs []int, elems []int) bool {
@@ -0,0 +1,279 @@
+// Implementation of Radix Trees (Patricia Tries)
+//
+// References:
+// - Radix tree: https://en.wikipedia.org/wiki/Radix_tree
+package trie
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	immutable_func "github.com/freebirdljj/immutable/func"
+)
+
+type (
+	// The zero value of `Trie` is an empty trie.
+	// Keys of type `[]byte` can be used by converting them to `string`.
+	Trie[Value any] struct {
+		cnt  int
+		root *node[Value]
+	}
+
+	// Every node but the root holds a value or at least 2 children,
+	// so that chains of single children are always compressed into one edge.
+	node[Value any] struct {
+		label    string         // the part of keys on the edge from the parent to this node
+		children []*node[Value] // sorted by the first bytes of their labels, which are distinct
+		hasValue bool
+		value    Value
+	}
+)
+
+func New[Value any]() *Trie[Value] {
+	return &Trie[Value]{}
+}
+
+func FromGoMap[Value any](goMap map[string]Value) *Trie[Value] {
+	t := New[Value]()
+	for key, value := range goMap {
+		t, _ = t.Insert(key, value)
+	}
+	return t
+}
+
+func (t *Trie[Value]) Empty() bool {
+	return t.cnt == 0
+}
+
+func (t *Trie[Value]) Count() int {
+	return t.cnt
+}
+
+func (t *Trie[Value]) Get(key string) (value Value, has bool) {
+
+	n := t.root
+	for n != nil && key != "" {
+		child := n.child(key[0])
+		if child == nil || !strings.HasPrefix(key, child.label) {
+			return immutable_func.Zero[Value](), false
+		}
+		n, key = child, key[len(child.label):]
+	}
+
+	if n == nil || !n.hasValue {
+		return immutable_func.Zero[Value](), false
+	}
+	return n.value, true
+}
+
+// `LongestPrefix(key)` returns the longest key in `t` which is a prefix of `key`, together with its value.
+func (t *Trie[Value]) LongestPrefix(key string) (prefix string, value Value, has bool) {
+
+	consumed := 0
+	for n := t.root; n != nil; {
+
+		if n.hasValue {
+			prefix, value, has = key[:consumed], n.value, true
+		}
+
+		if consumed == len(key) {
+			break
+		}
+
+		child := n.child(key[consumed])
+		if child == nil || !strings.HasPrefix(key[consumed:], child.label) {
+			break
+		}
+		n, consumed = child, consumed+len(child.label)
+	}
+
+	return prefix, value, has
+}
+
+// `All()` returns an iterator of all key-value pairs in ascending order of keys.
+func (t *Trie[Value]) All() iter.Seq2[string, Value] {
+	return t.WalkPrefix("")
+}
+
+// `WalkPrefix(prefix)` returns an iterator of all key-value pairs whose keys start with `prefix` in ascending order of keys.
+func (t *Trie[Value]) WalkPrefix(prefix string) iter.Seq2[string, Value] {
+	return func(yield func(string, Value) bool) {
+
+		n, key := t.root, []byte(nil)
+		for n != nil && len(key) < len(prefix) {
+			rest := prefix[len(key):]
+			child := n.child(rest[0])
+			if child == nil || !(strings.HasPrefix(rest, child.label) || strings.HasPrefix(child.label, rest)) {
+				return
+			}
+			n, key = child, append(key, child.label...)
+		}
+
+		n.traverse(key, yield)
+	}
+}
+
+// `newTrie` returned by `Insert()` is always different from the original one.
+// `affected` is true, meaning an actual insertion occurred; otherwise, a replacement occurred.
+func (t *Trie[Value]) Insert(key string, value Value) (newTrie *Trie[Value], affected bool) {
+
+	root := t.root
+	if root == nil {
+		root = &node[Value]{}
+	}
+
+	newRoot, affected := root.insert(key, value)
+	newTrie = &Trie[Value]{
+		cnt:  t.cnt,
+		root: newRoot,
+	}
+	if affected {
+		newTrie.cnt++
+	}
+	return newTrie, affected
+}
+
+// `affected` is true, meaning that a real deletion occurred, `newTrie` will be different from the original;
+// otherwise nothing happens, `newTrie` is the original one.
+func (t *Trie[Value]) Delete(key string) (newTrie *Trie[Value], affected bool) {
+
+	newRoot, affected := t.root.delete(key)
+	if !affected {
+		return t, false
+	}
+
+	if !newRoot.hasValue && len(newRoot.children) == 0 {
+		newRoot = nil
+	}
+
+	return &Trie[Value]{
+		cnt:  t.cnt - 1,
+		root: newRoot,
+	}, true
+}
+
+// `search()` returns the index of the child whose label starts with `b`, or where such a child should be inserted.
+func (n *node[Value]) search(b byte) (i int, found bool) {
+	return slices.BinarySearchFunc(n.children, b, func(child *node[Value], b byte) int {
+		return int(child.label[0]) - int(b)
+	})
+}
+
+func (n *node[Value]) child(b byte) *node[Value] {
+	i, found := n.search(b)
+	if !found {
+		return nil
+	}
+	return n.children[i]
+}
+
+func (n *node[Value]) traverse(key []byte, yield func(string, Value) bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	if n.hasValue && !yield(string(key), n.value) {
+		return false
+	}
+
+	for _, child := range n.children {
+		if !child.traverse(append(key, child.label...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// `insert()` inserts `value` with `key` relative to `n`.
+func (n *node[Value]) insert(key string, value Value) (newNode *node[Value], affected bool) {
+
+	nCopy := *n
+
+	if key == "" {
+		nCopy.hasValue, nCopy.value = true, value
+		return &nCopy, !n.hasValue
+	}
+
+	i, found := n.search(key[0])
+	if !found {
+		nCopy.children = slices.Insert(slices.Clip(n.children), i, &node[Value]{
+			label:    key,
+			hasValue: true,
+			value:    value,
+		})
+		return &nCopy, true
+	}
+
+	child := n.children[i]
+	common := commonPrefixLen(child.label, key)
+
+	newChild := (*node[Value])(nil)
+	if common == len(child.label) {
+		newChild, affected = child.insert(key[common:], value)
+	} else {
+		// NOTE: Split the edge of `child`, then the key lands either on the split point or on a new sibling of `child`.
+		childCopy := *child
+		childCopy.label = child.label[common:]
+		newChild, affected = (&node[Value]{
+			label:    child.label[:common],
+			children: []*node[Value]{&childCopy},
+		}).insert(key[common:], value)
+	}
+
+	nCopy.children = slices.Clone(n.children)
+	nCopy.children[i] = newChild
+	return &nCopy, affected
+}
+
+// `delete()` deletes `key` relative to `n`, the returned node may break the invariant of `node`,
+// which is left to the caller to restore.
+func (n *node[Value]) delete(key string) (newNode *node[Value], affected bool) {
+
+	if n == nil {
+		return nil, false
+	}
+
+	nCopy := *n
+
+	if key == "" {
+		if !n.hasValue {
+			return n, false
+		}
+		nCopy.hasValue, nCopy.value = false, immutable_func.Zero[Value]()
+		return &nCopy, true
+	}
+
+	i, found := n.search(key[0])
+	if !found || !strings.HasPrefix(key, n.children[i].label) {
+		return n, false
+	}
+
+	newChild, affected := n.children[i].delete(key[len(n.children[i].label):])
+	if !affected {
+		return n, false
+	}
+
+	switch {
+	case newChild.hasValue || len(newChild.children) > 1:
+		nCopy.children = slices.Clone(n.children)
+		nCopy.children[i] = newChild
+	case len(newChild.children) == 1:
+		grandchild := *newChild.children[0]
+		grandchild.label = newChild.label + grandchild.label
+		nCopy.children = slices.Clone(n.children)
+		nCopy.children[i] = &grandchild
+	default:
+		nCopy.children = slices.Delete(slices.Clone(n.children), i, i+1)
+	}
+	return &nCopy, true
+}
+
+func commonPrefixLen(l string, r string) int {
+	i := 0
+	for i < len(l) && i < len(r) && l[i] == r[i] {
+		i++
+	}
+	return i
+}
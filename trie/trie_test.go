@@ -0,0 +1,149 @@
+package trie
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/freebirdljj/immutable/internal/quick"
+)
+
+// `keysOf()` maps bytes to short keys over a tiny alphabet, so that keys share prefixes a lot.
+func keysOf(bs []byte) []string {
+	keys := make([]string, 0, len(bs))
+	for _, b := range bs {
+		keys = append(keys, strings.Repeat("a", int(b&3))+strings.Repeat("b", int(b>>2&3))+strings.Repeat("a", int(b>>4&1)))
+	}
+	return keys
+}
+
+func fromKeys(keys []string) (*Trie[int], map[string]int) {
+	t, goMap := New[int](), map[string]int{}
+	for i, key := range keys {
+		t, _ = t.Insert(key, i)
+		goMap[key] = i
+	}
+	return t, goMap
+}
+
+func TestTrieInsert(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"trie should behave like a go map": func(bs []byte, b byte) bool {
+
+			tr, goMap := fromKeys(keysOf(bs))
+			key := keysOf([]byte{b})[0]
+
+			value, has := tr.Get(key)
+			expected, expectedHas := goMap[key]
+			return value == expected && has == expectedHas &&
+				maps.Equal(maps.Collect(tr.All()), goMap) &&
+				tr.Count() == len(goMap) &&
+				isCompressed(tr.root, true)
+		},
+		"t.all() should be in ascending order of keys": func(bs []byte) bool {
+
+			tr, goMap := fromKeys(keysOf(bs))
+
+			keys := []string(nil)
+			for key := range tr.All() {
+				keys = append(keys, key)
+			}
+			return slices.Equal(keys, slices.Sorted(maps.Keys(goMap)))
+		},
+		"inserting should never affect the original trie": func(bs []byte, cs []byte) bool {
+
+			tr, goMap := fromKeys(keysOf(bs))
+			for _, key := range keysOf(cs) {
+				tr.Insert(key, -1)
+			}
+			return maps.Equal(maps.Collect(tr.All()), goMap)
+		},
+	})
+}
+
+func TestTrieDelete(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"deleting keys should behave like a go map": func(bs []byte, cs []byte) bool {
+
+			tr, goMap := fromKeys(keysOf(bs))
+			original := maps.Clone(goMap)
+
+			newTr := tr
+			for _, key := range keysOf(cs) {
+				_, has := goMap[key]
+				var affected bool
+				if newTr, affected = newTr.Delete(key); affected != has || !isCompressed(newTr.root, true) {
+					return false
+				}
+				delete(goMap, key)
+			}
+
+			return maps.Equal(maps.Collect(newTr.All()), goMap) &&
+				newTr.Count() == len(goMap) &&
+				maps.Equal(maps.Collect(tr.All()), original)
+		},
+		"deleting every key makes t empty": func(bs []byte) bool {
+
+			keys := keysOf(bs)
+			tr, _ := fromKeys(keys)
+			for _, key := range keys {
+				tr, _ = tr.Delete(key)
+			}
+			return tr.Empty() && tr.root == nil
+		},
+	})
+}
+
+func TestTriePrefix(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"t.walkPrefix(p) should yield exactly the keys starting with p": func(bs []byte, b byte) bool {
+
+			tr, goMap := fromKeys(keysOf(bs))
+			prefix := keysOf([]byte{b})[0]
+
+			expected := map[string]int{}
+			for key, value := range goMap {
+				if strings.HasPrefix(key, prefix) {
+					expected[key] = value
+				}
+			}
+			return maps.Equal(maps.Collect(tr.WalkPrefix(prefix)), expected)
+		},
+		"t.longestPrefix(k) should be the longest key being a prefix of k": func(bs []byte, b byte) bool {
+
+			tr, goMap := fromKeys(keysOf(bs))
+			key := keysOf([]byte{b})[0] + "ab"
+
+			expected, expectedHas := "", false
+			for k := range goMap {
+				if strings.HasPrefix(key, k) && (!expectedHas || len(k) > len(expected)) {
+					expected, expectedHas = k, true
+				}
+			}
+
+			prefix, value, has := tr.LongestPrefix(key)
+			return has == expectedHas && prefix == expected && (!has || value == goMap[prefix])
+		},
+	})
+}
+
+// `isCompressed()` reports whether every node below `n` holds a value or at least 2 children,
+// and children are sorted by distinct first bytes of their non-empty labels.
+func isCompressed[Value any](n *node[Value], isRoot bool) bool {
+
+	if n == nil {
+		return true
+	}
+
+	if !isRoot && (n.label == "" || (!n.hasValue && len(n.children) < 2)) {
+		return false
+	}
+
+	for i, child := range n.children {
+		if !isCompressed(child, false) || (i > 0 && n.children[i-1].label[0] >= child.label[0]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,8 @@
+package tuple
+
+type (
+	Pair[First any, Second any] struct {
+		First  First
+		Second Second
+	}
+)
@@ -0,0 +1,9 @@
+package tuple
+
+type (
+	Triple[First any, Second any, Third any] struct {
+		First  First
+		Second Second
+		Third  Third
+	}
+)
@@ -0,0 +1,428 @@
+// Implementation of Persistent Bit-partitioned Vector Tries
+//
+// References:
+// - Understanding Persistent Vector: https://hypirion.com/musings/understanding-persistent-vector-pt-1
+package vector
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/freebirdljj/immutable/maybe"
+	"github.com/freebirdljj/immutable/slice"
+)
+
+type (
+	// The zero value of `Vector` is an empty vector.
+	// The last (up to `branching`) elements are kept in `tail` rather than in the trie,
+	// so that `Push()` and `Pop()` mostly touch `tail` only.
+	// The i-th element is stored at index `offset` + i of the trie, so that slicing never moves elements.
+	Vector[T any] struct {
+		cnt    int
+		offset int  // the index of the first element in the trie, always 0 if `root` is nil
+		shift  uint // the number of index bits below the level of `root`
+		root   *node[T]
+		tail   []T
+	}
+
+	// A node is either a leaf holding exactly `branching` elements in `values`,
+	// or an internal node holding up to `branching` nodes in `children`,
+	// where children holding only indices before `offset` are dropped as nil.
+	node[T any] struct {
+		children []*node[T]
+		values   []T
+	}
+)
+
+const (
+	bitsPerLevel = 5
+	branching    = 1 << bitsPerLevel
+	levelMask    = branching - 1
+)
+
+func FromGoSlice[T any](xs []T) Vector[T] {
+
+	if len(xs) == 0 {
+		return Vector[T]{}
+	}
+
+	tailOffset := (len(xs) - 1) &^ levelMask
+
+	nodes := []*node[T](nil)
+	for i := 0; i < tailOffset; i += branching {
+		nodes = append(nodes, &node[T]{
+			values: slices.Clone(xs[i : i+branching]),
+		})
+	}
+
+	shift := uint(bitsPerLevel)
+	for ; len(nodes) > branching; shift += bitsPerLevel {
+		parents := make([]*node[T], 0, (len(nodes)+levelMask)/branching)
+		for chunk := range slices.Chunk(nodes, branching) {
+			parents = append(parents, &node[T]{
+				children: chunk,
+			})
+		}
+		nodes = parents
+	}
+
+	v := Vector[T]{
+		cnt:  len(xs),
+		tail: slices.Clone(xs[tailOffset:]),
+	}
+	if len(nodes) > 0 {
+		v.shift = shift
+		v.root = &node[T]{
+			children: nodes,
+		}
+	}
+	return v
+}
+
+func FromSlice[T any](xs slice.Slice[T]) Vector[T] {
+	return FromGoSlice(xs.ToGoSlice())
+}
+
+// CAUTION: Only invoke `FromSeq` with finite `seq`.
+func FromSeq[T any](seq iter.Seq[T]) Vector[T] {
+	return FromGoSlice(slices.Collect(seq))
+}
+
+func (v Vector[T]) Empty() bool {
+	return v.cnt == 0
+}
+
+func (v Vector[T]) Len() int {
+	return v.cnt
+}
+
+// CAUTION: Only invoke `Get(i)` with `i` in [0, `v.Len()`).
+func (v Vector[T]) Get(i int) T {
+	i += v.offset
+	return v.leafFor(i)[i-v.leafOffset(i)]
+}
+
+// `Set(i, x)` returns a vector with the `i`-th element replaced by `x`, copying O(log n) nodes.
+// CAUTION: Only invoke `Set(i, x)` with `i` in [0, `v.Len()`).
+func (v Vector[T]) Set(i int, x T) Vector[T] {
+
+	i += v.offset
+	if i >= v.tailOffset() {
+		newTail := slices.Clone(v.tail)
+		newTail[i-v.tailOffset()] = x
+		v.tail = newTail
+		return v
+	}
+
+	v.root = v.root.set(v.shift, i, x)
+	return v
+}
+
+// `Push(x)` returns a vector with `x` appended.
+func (v Vector[T]) Push(x T) Vector[T] {
+
+	if len(v.tail) < branching {
+		v.tail = append(slices.Clip(v.tail), x)
+		v.cnt++
+		return v
+	}
+
+	leaf := &node[T]{
+		values: v.tail,
+	}
+
+	switch {
+	case v.root == nil:
+		v.root = &node[T]{
+			children: []*node[T]{leaf},
+		}
+		v.shift = bitsPerLevel
+	case v.end()>>bitsPerLevel > 1<<v.shift:
+		// NOTE: The trie is full, so grow it by one level.
+		v.root = &node[T]{
+			children: []*node[T]{v.root, newPath(v.shift, leaf)},
+		}
+		v.shift += bitsPerLevel
+	default:
+		v.root = v.root.pushLeaf(v.shift, v.end()-1, leaf)
+	}
+
+	v.tail = []T{x}
+	v.cnt++
+	return v
+}
+
+// `Pop()` returns a vector with the last element removed, together with that element,
+// or nothing happens if `v` is empty.
+func (v Vector[T]) Pop() (newVector Vector[T], last maybe.Maybe[T]) {
+
+	switch {
+	case v.cnt == 0:
+		return v, maybe.Nothing[T]()
+	case len(v.tail) > 1 || v.root == nil:
+		last = maybe.Just(v.tail[len(v.tail)-1])
+		v.tail = v.tail[:len(v.tail)-1]
+		v.cnt--
+		if v.cnt == 0 {
+			return Vector[T]{}, last
+		}
+		return v, last
+	}
+
+	last = maybe.Just(v.tail[0])
+	newTailOffset := v.leafOffset(v.end() - 2)
+	if newTailOffset <= v.offset {
+		// NOTE: The last leaf of the trie is the first one holding elements, so only `tail` is left.
+		return Vector[T]{
+			cnt:  v.cnt - 1,
+			tail: v.leafFor(v.offset)[v.offset-newTailOffset:],
+		}, last
+	}
+
+	v.tail = v.leafFor(newTailOffset)
+	v.root = v.root.popLeaf(v.shift, newTailOffset)
+	v.cnt--
+	return v.collapse(), last
+}
+
+// `Slice(lo, hi)` returns a vector of elements with indices in [`lo`, `hi`), copying O(log n) nodes.
+// CAUTION: Only invoke `Slice(lo, hi)` with 0 <= `lo` <= `hi` <= `v.Len()`.
+func (v Vector[T]) Slice(lo int, hi int) Vector[T] {
+	return v.take(hi).drop(lo)
+}
+
+// `All()` returns an iterator of all elements in order.
+func (v Vector[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := v.offset; i < v.end(); i = v.leafOffset(i) + branching {
+			for _, x := range v.leafFor(i)[i-v.leafOffset(i):] {
+				if !yield(x) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (v Vector[T]) ToSlice() slice.Slice[T] {
+	return slice.FromGoSlice(v.ToGoSlice())
+}
+
+func (v Vector[T]) ToGoSlice() []T {
+
+	if v.cnt == 0 {
+		return nil
+	}
+
+	xs := make([]T, 0, v.cnt)
+	for i := v.offset; i < v.end(); i = v.leafOffset(i) + branching {
+		xs = append(xs, v.leafFor(i)[i-v.leafOffset(i):]...)
+	}
+	return xs
+}
+
+// `take(n)` returns a vector of the first `n` elements.
+func (v Vector[T]) take(n int) Vector[T] {
+
+	switch {
+	case n == v.cnt:
+		return v
+	case n == 0:
+		return Vector[T]{}
+	}
+
+	end := v.offset + n
+	if end > v.tailOffset() {
+		v.tail = v.tail[:end-v.tailOffset()]
+		v.cnt = n
+		return v
+	}
+
+	newTailOffset := v.leafOffset(end - 1)
+	if newTailOffset <= v.offset {
+		return Vector[T]{
+			cnt:  n,
+			tail: v.leafFor(v.offset)[v.offset-newTailOffset : end-newTailOffset],
+		}
+	}
+
+	v.tail = v.leafFor(newTailOffset)[:end-newTailOffset]
+	v.root = v.root.truncate(v.shift, newTailOffset)
+	v.cnt = n
+	return v.collapse()
+}
+
+// `drop(n)` returns a vector without the first `n` elements,
+// dropping nodes holding only dropped elements.
+func (v Vector[T]) drop(n int) Vector[T] {
+
+	switch {
+	case n == 0:
+		return v
+	case n == v.cnt:
+		return Vector[T]{}
+	}
+
+	offset := v.offset + n
+	if offset >= v.tailOffset() {
+		return Vector[T]{
+			cnt:  v.cnt - n,
+			tail: v.tail[offset-v.tailOffset():],
+		}
+	}
+
+	v.root = v.root.dropBefore(v.shift, offset)
+	v.offset = offset
+	v.cnt -= n
+	return v.collapse()
+}
+
+// `end()` returns the index of the trie after the last element.
+func (v Vector[T]) end() int {
+	return v.offset + v.cnt
+}
+
+// `tailOffset()` returns the index of the trie of the first element in `tail`.
+func (v Vector[T]) tailOffset() int {
+	return v.end() - len(v.tail)
+}
+
+// `leafOffset(i)` returns the index of the trie of the first element in the leaf (or `tail`) containing index `i`.
+func (v Vector[T]) leafOffset(i int) int {
+	if i >= v.tailOffset() {
+		return v.tailOffset()
+	}
+	return i &^ levelMask
+}
+
+// `collapse()` removes the redundant levels above the trie, whose root holds a single child with elements.
+func (v Vector[T]) collapse() Vector[T] {
+
+	for v.shift > bitsPerLevel {
+		j := (v.offset >> v.shift) & levelMask
+		if j != len(v.root.children)-1 {
+			break
+		}
+		v.root = v.root.children[j]
+		v.offset -= j << v.shift
+		v.shift -= bitsPerLevel
+	}
+	return v
+}
+
+// `leafFor(i)` returns the elements of the leaf (or `tail`) containing index `i` of the trie,
+// which must not be modified.
+func (v Vector[T]) leafFor(i int) []T {
+
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+
+	n := v.root
+	for shift := v.shift; shift > 0; shift -= bitsPerLevel {
+		n = n.children[(i>>shift)&levelMask]
+	}
+	return n.values
+}
+
+// `newPath(shift, leaf)` returns a chain of nodes from the level of `shift` down to `leaf`.
+func newPath[T any](shift uint, leaf *node[T]) *node[T] {
+	for ; shift > 0; shift -= bitsPerLevel {
+		leaf = &node[T]{
+			children: []*node[T]{leaf},
+		}
+	}
+	return leaf
+}
+
+func (n *node[T]) set(shift uint, i int, x T) *node[T] {
+
+	if shift == 0 {
+		newValues := slices.Clone(n.values)
+		newValues[i&levelMask] = x
+		return &node[T]{
+			values: newValues,
+		}
+	}
+
+	j := (i >> shift) & levelMask
+	newChildren := slices.Clone(n.children)
+	newChildren[j] = n.children[j].set(shift-bitsPerLevel, i, x)
+	return &node[T]{
+		children: newChildren,
+	}
+}
+
+// `pushLeaf()` appends `leaf`, whose last element has index `last`, to the trie rooted at `n`.
+func (n *node[T]) pushLeaf(shift uint, last int, leaf *node[T]) *node[T] {
+
+	j := (last >> shift) & levelMask
+
+	newChild := leaf
+	switch {
+	case shift == bitsPerLevel:
+	case j < len(n.children):
+		newChild = n.children[j].pushLeaf(shift-bitsPerLevel, last, leaf)
+	default:
+		newChild = newPath(shift-bitsPerLevel, leaf)
+	}
+
+	newChildren := slices.Clone(n.children[:j])
+	return &node[T]{
+		children: append(newChildren, newChild),
+	}
+}
+
+// `popLeaf()` removes the last leaf, which contains index `last`, from the trie rooted at `n`,
+// returning nil if nothing is left.
+func (n *node[T]) popLeaf(shift uint, last int) *node[T] {
+
+	j := (last >> shift) & levelMask
+
+	newChildren := slices.Clone(n.children[:j])
+	if shift > bitsPerLevel {
+		if newChild := n.children[j].popLeaf(shift-bitsPerLevel, last); newChild != nil {
+			newChildren = append(newChildren, newChild)
+		}
+	}
+
+	if len(newChildren) == 0 {
+		return nil
+	}
+	return &node[T]{
+		children: newChildren,
+	}
+}
+
+// `truncate()` returns the trie rooted at `n` with only elements with indices less than `limit` kept,
+// where `limit` must be a positive multiple of `branching`.
+func (n *node[T]) truncate(shift uint, limit int) *node[T] {
+
+	j := ((limit - 1) >> shift) & levelMask
+
+	if shift == bitsPerLevel {
+		return &node[T]{
+			children: slices.Clip(n.children[:j+1]),
+		}
+	}
+
+	newChildren := slices.Clone(n.children[:j])
+	return &node[T]{
+		children: append(newChildren, n.children[j].truncate(shift-bitsPerLevel, limit)),
+	}
+}
+
+// `dropBefore()` returns the trie rooted at `n` with children holding only indices less than `start` dropped as nil.
+func (n *node[T]) dropBefore(shift uint, start int) *node[T] {
+
+	j := (start >> shift) & levelMask
+
+	newChildren := slices.Clone(n.children)
+	clear(newChildren[:j])
+	if shift > bitsPerLevel {
+		newChildren[j] = n.children[j].dropBefore(shift-bitsPerLevel, start)
+	}
+	return &node[T]{
+		children: newChildren,
+	}
+}
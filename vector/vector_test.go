@@ -0,0 +1,216 @@
+package vector
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/freebirdljj/immutable/internal/quick"
+	"github.com/freebirdljj/immutable/slice"
+)
+
+// `xsOfLength(n)` returns [0, `n` mod 4096), which is long enough to grow the trie up to 3 levels.
+func xsOfLength(n uint16) []int {
+	xs := make([]int, n%(1<<12))
+	for i := range xs {
+		xs[i] = i
+	}
+	return xs
+}
+
+func TestVectorFromGoSlice(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"fromGoSlice(xs).toGoSlice() == xs": func(n uint16) bool {
+			xs := xsOfLength(n)
+			v := FromGoSlice(xs)
+			return slices.Equal(v.ToGoSlice(), xs) && slices.Equal(slices.Collect(v.All()), xs) && v.Len() == len(xs) && isCompact(v)
+		},
+		"fromSlice(xs).toSlice() == xs": func(xs []string) bool {
+			return slices.Equal(FromSlice(slice.FromGoSlice(xs)).ToSlice(), xs)
+		},
+	})
+}
+
+func TestVectorPush(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"pushing all of xs one by one == fromGoSlice(xs)": func(n uint16) bool {
+
+			xs := xsOfLength(n)
+
+			v := Vector[int]{}
+			for _, x := range xs {
+				v = v.Push(x)
+			}
+
+			return slices.Equal(v.ToGoSlice(), xs) && v.Len() == len(xs) && isCompact(v)
+		},
+		"pushing should never affect the original vector": func(n uint16, x int, y int) bool {
+
+			xs := xsOfLength(n)
+			v := FromGoSlice(xs)
+
+			vx, vy := v.Push(x), v.Push(y)
+			return slices.Equal(v.ToGoSlice(), xs) &&
+				slices.Equal(vx.ToGoSlice(), append(slices.Clone(xs), x)) &&
+				slices.Equal(vy.ToGoSlice(), append(slices.Clone(xs), y))
+		},
+	})
+}
+
+func TestVectorPop(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"popping all of v yields v in reverse order and then nothing": func(n uint16) bool {
+
+			xs := xsOfLength(n)
+			v := FromGoSlice(xs)
+
+			for i := len(xs) - 1; i >= 0; i-- {
+				newV, last := v.Pop()
+				if last.IsNothing() || last.Value() != xs[i] || newV.Len() != i || !isCompact(newV) {
+					return false
+				}
+				v = newV
+			}
+
+			newV, last := v.Pop()
+			return last.IsNothing() && newV.Empty() && isCompact(newV)
+		},
+		"v.pop() == fromGoSlice(xs[:len(xs)-1])": func(n uint16) bool {
+
+			xs := xsOfLength(n)
+			v, _ := FromGoSlice(xs).Pop()
+			return slices.Equal(v.ToGoSlice(), xs[:max(len(xs)-1, 0)])
+		},
+	})
+}
+
+func TestVectorGetAndSet(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"v.set(i, x).get(i) == x and other elements stay intact": func(n uint16, i uint16, x int) bool {
+
+			xs := xsOfLength(n)
+			xs = append(xs, len(xs))
+			j := int(i) % len(xs)
+			v := FromGoSlice(xs)
+
+			newV := v.Set(j, x)
+			for k := range xs {
+				if v.Get(k) != xs[k] || (k != j && newV.Get(k) != xs[k]) {
+					return false
+				}
+			}
+			return newV.Get(j) == x && newV.Len() == v.Len()
+		},
+	})
+}
+
+func TestVectorSlice(t *testing.T) {
+	quick.CheckProperties(t, map[string]any{
+		"v.slice(lo, hi) == xs[lo:hi]": func(n uint16, lo uint16, hi uint16) bool {
+
+			xs := xsOfLength(n)
+			l, h := int(lo)%(len(xs)+1), int(hi)%(len(xs)+1)
+			l, h = min(l, h), max(l, h)
+
+			sliced := FromGoSlice(xs).Slice(l, h)
+			return slices.Equal(sliced.ToGoSlice(), xs[l:h]) && sliced.Len() == h-l && isCompact(sliced) &&
+				slices.Equal(sliced.Push(-1).ToGoSlice(), append(slices.Clone(xs[l:h]), -1))
+		},
+		"sliced vectors should behave like go slices": func(n uint16, ops []uint16) bool {
+
+			xs := xsOfLength(n)
+			v := FromGoSlice(xs)
+
+			for _, op := range ops {
+
+				switch op % 4 {
+				case 0:
+					lo := int(op/4) % (len(xs) + 1)
+					v, xs = v.Slice(lo, len(xs)), xs[lo:]
+				case 1:
+					v, xs = v.Push(int(op)), append(slices.Clip(xs), int(op))
+				case 2:
+					v, _ = v.Pop()
+					xs = xs[:max(len(xs)-1, 0)]
+				case 3:
+					if len(xs) > 0 {
+						i := int(op/4) % len(xs)
+						v, xs = v.Set(i, int(op)), slices.Clone(xs)
+						xs[i] = int(op)
+					}
+				}
+
+				if !slices.Equal(v.ToGoSlice(), xs) || !slices.Equal(slices.Collect(v.All()), xs) || v.Len() != len(xs) || !isCompact(v) {
+					return false
+				}
+				for i, x := range xs {
+					if v.Get(i) != x {
+						return false
+					}
+				}
+			}
+			return true
+		},
+	})
+}
+
+// `isCompact()` reports whether `v` has no redundant levels, a non-empty tail unless empty,
+// all leaves holding elements full, and no nodes holding only indices before `offset`.
+func isCompact[T any](v Vector[T]) bool {
+
+	if v.cnt == 0 {
+		return v.root == nil && len(v.tail) == 0 && v.offset == 0
+	}
+
+	if len(v.tail) == 0 || len(v.tail) > branching {
+		return false
+	}
+
+	if v.root == nil {
+		return v.shift == 0 && v.offset == 0 && len(v.tail) == v.cnt
+	}
+
+	if v.offset >= v.tailOffset() || v.tailOffset()&levelMask != 0 {
+		return false
+	}
+
+	if v.shift > bitsPerLevel && (v.offset>>v.shift)&levelMask == len(v.root.children)-1 {
+		return false
+	}
+
+	// `check()` returns the number of indices covered by `n`, whose first index is `start`, or -1 if not compact.
+	var check func(n *node[T], shift uint, start int) int
+	check = func(n *node[T], shift uint, start int) int {
+
+		end := start + 1<<(shift+bitsPerLevel)
+
+		if n == nil {
+			if end > v.offset {
+				return -1
+			}
+			return end - start
+		}
+
+		if end <= v.offset {
+			return -1
+		}
+
+		if shift == 0 {
+			if len(n.values) != branching {
+				return -1
+			}
+			return branching
+		}
+
+		cnt := 0
+		for j, child := range n.children {
+			childCnt := check(child, shift-bitsPerLevel, start+j<<shift)
+			if childCnt < 0 {
+				return -1
+			}
+			cnt += childCnt
+		}
+		return cnt
+	}
+
+	return check(v.root, v.shift, 0)+len(v.tail) == v.end()
+}